@@ -57,7 +57,7 @@ func storeToDatum(s StoreRecord, db *DB) jsonapi.Datum[StoreAttributes] {
 			"pets": {
 				Links: jsonapi.Links{
 					"self":    jsonapi.StringLink(baseURL + "/stores/" + s.ID + "/relationships/pets"),
-					"related": jsonapi.StringLink(baseURL + "/stores/" + s.ID + "/pets"),
+					"related": jsonapi.RelatedLink(baseURL + "/stores/" + s.ID + "/pets"),
 				},
 				Data: jsonapi.ResourceLinkageCollection(db.petLinkage(s.ID)),
 			},
@@ -90,7 +90,7 @@ func petToDatum(p PetRecord, db *DB) jsonapi.Datum[PetAttributes] {
 			"store": {
 				Links: jsonapi.Links{
 					"self":    jsonapi.StringLink(baseURL + "/pets/" + p.ID + "/relationships/store"),
-					"related": jsonapi.StringLink(baseURL + "/stores/" + p.StoreID),
+					"related": jsonapi.RelatedLink(baseURL + "/stores/" + p.StoreID),
 				},
 				Data: jsonapi.ResourceIdentifierLinkage{Type: "stores", ID: p.StoreID},
 			},
@@ -107,7 +107,7 @@ func (s *Server) listStores(w http.ResponseWriter, r *http.Request) {
 	}
 	writeJSON(w, http.StatusOK, jsonapi.DatumCollectionEnvelope[StoreAttributes]{
 		Data:  data,
-		Links: selfLink("/stores"),
+		Links: jsonapi.SelfLinkFromRequest(r),
 	})
 }
 
@@ -119,7 +119,7 @@ func (s *Server) getStore(w http.ResponseWriter, r *http.Request, id string) {
 	}
 	writeJSON(w, http.StatusOK, jsonapi.SingleDatumEnvelope[StoreAttributes]{
 		Data:  storeToDatum(store, s.db),
-		Links: selfLink("/stores/" + id),
+		Links: jsonapi.SelfLinkFromRequest(r),
 	})
 }
 
@@ -182,7 +182,7 @@ func (s *Server) updateStore(w http.ResponseWriter, r *http.Request, id string)
 	}
 	writeJSON(w, http.StatusOK, jsonapi.SingleDatumEnvelope[StoreAttributes]{
 		Data:  storeToDatum(updated, s.db),
-		Links: selfLink("/stores/" + id),
+		Links: jsonapi.SelfLinkFromRequest(r),
 	})
 }
 
@@ -202,7 +202,7 @@ func (s *Server) listPets(w http.ResponseWriter, r *http.Request) {
 	}
 	writeJSON(w, http.StatusOK, jsonapi.DatumCollectionEnvelope[PetAttributes]{
 		Data:  data,
-		Links: selfLink("/pets"),
+		Links: jsonapi.SelfLinkFromRequest(r),
 	})
 }
 
@@ -214,7 +214,7 @@ func (s *Server) getPet(w http.ResponseWriter, r *http.Request, id string) {
 	}
 	writeJSON(w, http.StatusOK, jsonapi.SingleDatumEnvelope[PetAttributes]{
 		Data:  petToDatum(pet, s.db),
-		Links: selfLink("/pets/" + id),
+		Links: jsonapi.SelfLinkFromRequest(r),
 	})
 }
 
@@ -282,7 +282,7 @@ func (s *Server) updatePet(w http.ResponseWriter, r *http.Request, id string) {
 	}
 	writeJSON(w, http.StatusOK, jsonapi.SingleDatumEnvelope[PetAttributes]{
 		Data:  petToDatum(updated, s.db),
-		Links: selfLink("/pets/" + id),
+		Links: jsonapi.SelfLinkFromRequest(r),
 	})
 }
 
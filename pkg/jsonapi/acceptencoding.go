@@ -0,0 +1,69 @@
+package jsonapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EncodingPreference is one content coding listed in an Accept-Encoding request header with its quality value.
+type EncodingPreference struct {
+	Coding  string
+	Quality float64
+}
+
+// AcceptEncoding is the list of content codings from an Accept-Encoding header, highest quality first.
+// It is informational only: Accept-Encoding is not part of JSON:API and is never validated by HeaderRuleSet.
+type AcceptEncoding []EncodingPreference
+
+// ParseAcceptEncoding parses an Accept-Encoding header value (RFC 9110), e.g. "gzip, br;q=0.8, *;q=0".
+// Codings are lower-cased and sorted by quality, keeping header order for equal qualities.
+// Entries with a malformed quality value are skipped rather than reported.
+func ParseAcceptEncoding(value string) AcceptEncoding {
+	var out AcceptEncoding
+	for _, item := range strings.Split(value, ",") {
+		coding, params, _ := strings.Cut(item, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		quality := 1.0
+		if name, q, ok := strings.Cut(params, "="); ok && strings.EqualFold(strings.TrimSpace(name), "q") {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(q), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				continue
+			}
+			quality = parsed
+		}
+		out = append(out, EncodingPreference{Coding: coding, Quality: quality})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Quality > out[j].Quality
+	})
+	return out
+}
+
+// AcceptEncodingFromHeader parses every Accept-Encoding value of the request headers.
+func AcceptEncodingFromHeader(headers http.Header) AcceptEncoding {
+	return ParseAcceptEncoding(strings.Join(headers.Values("Accept-Encoding"), ","))
+}
+
+// Accepts reports whether the client accepts coding, either by name or through "*".
+// A coding with quality 0 is refused. An empty list accepts every coding.
+func (a AcceptEncoding) Accepts(coding string) bool {
+	if len(a) == 0 {
+		return true
+	}
+	coding = strings.ToLower(coding)
+	wildcard := -1.0
+	for _, preference := range a {
+		if preference.Coding == coding {
+			return preference.Quality > 0
+		}
+		if preference.Coding == "*" && wildcard < 0 {
+			wildcard = preference.Quality
+		}
+	}
+	return wildcard > 0
+}
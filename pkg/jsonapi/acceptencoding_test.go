@@ -0,0 +1,43 @@
+package jsonapi_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+)
+
+// Requirements:
+// - Codings are parsed with their quality values and sorted by quality.
+// - Malformed entries are skipped.
+// - Accepts honours explicit codings, q=0, and the wildcard.
+func TestAcceptEncoding(t *testing.T) {
+	headers := http.Header{}
+	headers.Add("Accept-Encoding", "deflate;q=0.5, GZIP")
+	headers.Add("Accept-Encoding", "br;q=0.8, identity;q=0, compress;q=abc, *;q=0.1")
+
+	got := jsonapi.AcceptEncodingFromHeader(headers)
+	want := jsonapi.AcceptEncoding{
+		{Coding: "gzip", Quality: 1},
+		{Coding: "br", Quality: 0.8},
+		{Coding: "deflate", Quality: 0.5},
+		{Coding: "*", Quality: 0.1},
+		{Coding: "identity", Quality: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	for coding, accepted := range map[string]bool{"gzip": true, "br": true, "identity": false, "zstd": true} {
+		if got.Accepts(coding) != accepted {
+			t.Errorf("Expected Accepts(%q) to be %v", coding, accepted)
+		}
+	}
+	if jsonapi.ParseAcceptEncoding("gzip").Accepts("br") {
+		t.Error("Expected br to be refused when not listed and there is no wildcard")
+	}
+	if !jsonapi.AcceptEncodingFromHeader(http.Header{}).Accepts("gzip") {
+		t.Error("Expected every coding to be accepted without an Accept-Encoding header")
+	}
+}
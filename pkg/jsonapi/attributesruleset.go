@@ -13,7 +13,9 @@ import (
 // member name per JSON:API (MemberNameRule); WithKeyUnsafe skips that check.
 // All other ObjectRuleSet methods are delegated to the inner rule set.
 type AttributesRuleSet struct {
-	inner *rules.ObjectRuleSet[map[string]any, string, any]
+	inner         *rules.ObjectRuleSet[map[string]any, string, any]
+	nameTransform func(string) string
+	internalNames map[string]string // external name -> registered (internal) name
 }
 
 // Attributes returns a new attributes rule set backed by rules.StringMap[any]().
@@ -32,43 +34,132 @@ func (a *AttributesRuleSet) mustValidMemberName(name string) {
 	}
 }
 
+// with returns a copy of the rule set using inner, keeping the name transform and registered names.
+func (a *AttributesRuleSet) with(inner *rules.ObjectRuleSet[map[string]any, string, any]) *AttributesRuleSet {
+	return &AttributesRuleSet{inner: inner, nameTransform: a.nameTransform, internalNames: a.internalNames}
+}
+
+// withName is like with but also records that the attribute registered as internal appears as external in documents.
+func (a *AttributesRuleSet) withName(external, internal string, inner *rules.ObjectRuleSet[map[string]any, string, any]) *AttributesRuleSet {
+	out := a.with(inner)
+	if external == internal {
+		return out
+	}
+	out.internalNames = make(map[string]string, len(a.internalNames)+1)
+	for k, v := range a.internalNames {
+		out.internalNames[k] = v
+	}
+	out.internalNames[external] = internal
+	return out
+}
+
+// externalName returns the document name for an attribute registered as name.
+func (a *AttributesRuleSet) externalName(name string) string {
+	if a.nameTransform == nil {
+		return name
+	}
+	return a.nameTransform(name)
+}
+
+// WithNameTransform sets fn to map registered attribute names to the names used in documents,
+// e.g. snake_case to camelCase. Keys registered afterwards are validated under their transformed
+// names (which must be valid member names), and Apply returns them under the registered names.
+// Datums decoded by DatumRuleSet carry the mapping in NameTransform, so marshaling them writes the
+// transformed names again. Keys registered before WithNameTransform and keys accepted by WithUnknown
+// or WithDynamicKey are not transformed.
+func (a *AttributesRuleSet) WithNameTransform(fn func(string) string) *AttributesRuleSet {
+	out := a.with(a.inner)
+	out.nameTransform = fn
+	return out
+}
+
+// externalNames returns a function that maps registered attribute names to their document names, or nil
+// if no registered name is transformed. Other names are returned unchanged.
+func (a *AttributesRuleSet) externalNames() func(string) string {
+	if len(a.internalNames) == 0 {
+		return nil
+	}
+	names := make(map[string]string, len(a.internalNames))
+	for external, internal := range a.internalNames {
+		names[internal] = external
+	}
+	return func(name string) string {
+		if external, ok := names[name]; ok {
+			return external
+		}
+		return name
+	}
+}
+
+// externalAttributes returns a copy of attrs with registered names replaced by document names.
+func (a *AttributesRuleSet) externalAttributes(attrs map[string]any) map[string]any {
+	externalName := a.externalNames()
+	if externalName == nil || attrs == nil {
+		return attrs
+	}
+	out := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		out[externalName(k)] = v
+	}
+	return out
+}
+
+// internalAttributes returns a copy of attrs with document names replaced by registered names.
+func (a *AttributesRuleSet) internalAttributes(attrs map[string]any) map[string]any {
+	if len(a.internalNames) == 0 || attrs == nil {
+		return attrs
+	}
+	out := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		if internal, ok := a.internalNames[k]; ok {
+			k = internal
+		}
+		out[k] = v
+	}
+	return out
+}
+
 // WithKey registers an attribute key and its rule set; panics if key is not a valid
 // JSON:API member name (empty or contains reserved characters). Use MemberNameRule.Evaluate
 // or WithKeyUnsafe to avoid panic when the key may be invalid.
 func (a *AttributesRuleSet) WithKey(name string, ruleSet rules.RuleSet[any]) *AttributesRuleSet {
-	a.mustValidMemberName(name)
-	return &AttributesRuleSet{inner: a.inner.WithKey(name, ruleSet)}
+	external := a.externalName(name)
+	a.mustValidMemberName(external)
+	return a.withName(external, name, a.inner.WithKey(external, ruleSet))
 }
 
 // WithKeyUnsafe registers an attribute key without validating the key name.
 func (a *AttributesRuleSet) WithKeyUnsafe(name string, ruleSet rules.RuleSet[any]) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithKey(name, ruleSet)}
+	external := a.externalName(name)
+	return a.withName(external, name, a.inner.WithKey(external, ruleSet))
 }
 
 // WithConditionalKey registers a conditional attribute key; panics if key is not a valid JSON:API member name.
 func (a *AttributesRuleSet) WithConditionalKey(key string, condition rules.Conditional[map[string]any, string], ruleSet rules.RuleSet[any]) *AttributesRuleSet {
-	a.mustValidMemberName(key)
-	return &AttributesRuleSet{inner: a.inner.WithConditionalKey(key, condition, ruleSet)}
+	external := a.externalName(key)
+	a.mustValidMemberName(external)
+	return a.withName(external, key, a.inner.WithConditionalKey(external, condition, ruleSet))
 }
 
 // WithConditionalKeyUnsafe registers a conditional attribute key without validating the key name.
 func (a *AttributesRuleSet) WithConditionalKeyUnsafe(key string, condition rules.Conditional[map[string]any, string], ruleSet rules.RuleSet[any]) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithConditionalKey(key, condition, ruleSet)}
+	external := a.externalName(key)
+	return a.withName(external, key, a.inner.WithConditionalKey(external, condition, ruleSet))
 }
 
 // WithDynamicKey adds a validation rule for any key that matches the key rule.
 func (a *AttributesRuleSet) WithDynamicKey(keyRule rules.Rule[string], ruleSet rules.RuleSet[any]) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithDynamicKey(keyRule, ruleSet)}
+	return a.with(a.inner.WithDynamicKey(keyRule, ruleSet))
 }
 
 // WithDynamicBucket puts matching keys into the named bucket (map key).
 func (a *AttributesRuleSet) WithDynamicBucket(keyRule rules.Rule[string], bucket string) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithDynamicBucket(keyRule, bucket)}
+	return a.with(a.inner.WithDynamicBucket(keyRule, bucket))
 }
 
 // WithConditionalDynamicBucket puts matching keys into the bucket when the condition is met.
 func (a *AttributesRuleSet) WithConditionalDynamicBucket(keyRule rules.Rule[string], condition rules.Conditional[map[string]any, string], bucket string) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithConditionalDynamicBucket(keyRule, condition, bucket)}
+	return a.with(a.inner.WithConditionalDynamicBucket(keyRule, condition, bucket))
 }
 
 // KeyRules returns the key rules that have rule sets associated with them.
@@ -78,67 +169,73 @@ func (a *AttributesRuleSet) KeyRules() []rules.Rule[string] {
 
 // WithUnknown allows any attribute key (dynamic attributes).
 func (a *AttributesRuleSet) WithUnknown() *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithUnknown()}
+	return a.with(a.inner.WithUnknown())
 }
 
 // WithRequired returns a new rule set that requires the value to be present when nested.
 func (a *AttributesRuleSet) WithRequired() *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithRequired()}
+	return a.with(a.inner.WithRequired())
 }
 
 // WithJson allows the input to be a JSON-encoded string.
 func (a *AttributesRuleSet) WithJson() *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithJson()}
+	return a.with(a.inner.WithJson())
 }
 
 // WithRule adds a custom validation rule over the entire attributes object.
 func (a *AttributesRuleSet) WithRule(rule rules.Rule[map[string]any]) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithRule(rule)}
+	return a.with(a.inner.WithRule(rule))
 }
 
 // WithRuleFunc adds a custom validation function over the entire attributes object.
 func (a *AttributesRuleSet) WithRuleFunc(rule rules.RuleFunc[map[string]any]) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithRuleFunc(rule)}
+	return a.with(a.inner.WithRuleFunc(rule))
 }
 
 // WithErrorMessage sets custom short and long error messages.
 func (a *AttributesRuleSet) WithErrorMessage(short, long string) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithErrorMessage(short, long)}
+	return a.with(a.inner.WithErrorMessage(short, long))
 }
 
 // WithDocsURI sets a documentation URI on validation errors.
 func (a *AttributesRuleSet) WithDocsURI(uri string) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithDocsURI(uri)}
+	return a.with(a.inner.WithDocsURI(uri))
 }
 
 // WithTraceURI sets a trace/debug URI on validation errors.
 func (a *AttributesRuleSet) WithTraceURI(uri string) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithTraceURI(uri)}
+	return a.with(a.inner.WithTraceURI(uri))
 }
 
 // WithErrorCode overrides the error code for validation errors.
 func (a *AttributesRuleSet) WithErrorCode(code errors.ErrorCode) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithErrorCode(code)}
+	return a.with(a.inner.WithErrorCode(code))
 }
 
 // WithErrorMeta adds metadata to validation errors.
 func (a *AttributesRuleSet) WithErrorMeta(key string, value any) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithErrorMeta(key, value)}
+	return a.with(a.inner.WithErrorMeta(key, value))
 }
 
 // WithErrorCallback sets a callback for custom error processing.
 func (a *AttributesRuleSet) WithErrorCallback(fn errors.ErrorCallback) *AttributesRuleSet {
-	return &AttributesRuleSet{inner: a.inner.WithErrorCallback(fn)}
+	return a.with(a.inner.WithErrorCallback(fn))
 }
 
 // Apply implements rules.RuleSet[map[string]any].
+// Attributes with transformed names are returned under their registered names.
 func (a *AttributesRuleSet) Apply(ctx context.Context, input any) (map[string]any, errors.ValidationError) {
-	return a.inner.Apply(ctx, input)
+	out, errs := a.inner.Apply(ctx, input)
+	if errs != nil {
+		return out, errs
+	}
+	return a.internalAttributes(out), nil
 }
 
 // Evaluate implements rules.RuleSet[map[string]any].
+// value uses registered names, as returned by Apply.
 func (a *AttributesRuleSet) Evaluate(ctx context.Context, value map[string]any) errors.ValidationError {
-	return a.inner.Evaluate(ctx, value)
+	return a.inner.Evaluate(ctx, a.externalAttributes(value))
 }
 
 // Required implements rules.RuleSet[map[string]any].
@@ -158,5 +255,8 @@ func (a *AttributesRuleSet) Replaces(r rules.Rule[map[string]any]) bool {
 
 // Any implements rules.RuleSet[map[string]any].
 func (a *AttributesRuleSet) Any() rules.RuleSet[any] {
+	if len(a.internalNames) > 0 {
+		return rules.WrapAny[map[string]any](a)
+	}
 	return a.inner.Any()
 }
@@ -2,6 +2,7 @@ package jsonapi_test
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -126,3 +127,74 @@ func TestAttributesRuleSet_WithConditionalKeyAndDynamic(t *testing.T) {
 		t.Fatalf("Apply: %s", errs)
 	}
 }
+
+// snakeToCamel converts a snake_case name to camelCase.
+func snakeToCamel(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// Requirements:
+// - Keys registered after WithNameTransform are validated under their transformed names.
+// - Apply returns attributes under the registered names.
+// - Errors and Fields use the document names.
+// - Marshaling a decoded datum writes every attribute under its document name again.
+func TestAttributesRuleSet_WithNameTransform(t *testing.T) {
+	rs := jsonapi.Attributes().
+		WithNameTransform(snakeToCamel).
+		WithKey("first_name", rules.String().WithMinLen(1).Any()).
+		WithKey("age", rules.Int().Any())
+	ctx := context.Background()
+
+	out, errs := rs.Apply(ctx, map[string]any{"firstName": "Ada", "age": 36})
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if out["first_name"] != "Ada" {
+		t.Errorf("Expected first_name=Ada, got: %v", out)
+	}
+	if _, ok := out["firstName"]; ok {
+		t.Errorf("Expected firstName to be renamed, got: %v", out)
+	}
+	if errs := rs.Evaluate(ctx, out); errs != nil {
+		t.Errorf("Expected Evaluate errors to be nil, got: %s", errs)
+	}
+
+	if _, errs := rs.Apply(ctx, map[string]any{"first_name": "Ada"}); errs == nil {
+		t.Error("Expected the registered name to be rejected in documents, got: nil")
+	}
+
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("people", rs)
+	envelope, errs := ruleSet.Apply(ctx, `{"data": {"type": "people", "id": "1", "attributes": {"firstName": "Ada", "age": 36}}}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if envelope.Data.Attributes["first_name"] != "Ada" {
+		t.Errorf("Expected first_name=Ada, got: %v", envelope.Data.Attributes)
+	}
+	if !envelope.Data.Fields.Contains("firstName") || !envelope.Data.Fields.Contains("age") {
+		t.Errorf("Expected Fields to contain firstName and age, got: %v", envelope.Data.Fields.Values())
+	}
+
+	marshaled, err := json.Marshal(envelope.Data)
+	if err != nil {
+		t.Fatalf("Expected marshal error to be nil, got: %s", err)
+	}
+	if expected := `{"id":"1","type":"people","attributes":{"firstName":"Ada","age":36}}`; !jsonEqual(expected, string(marshaled)) {
+		t.Errorf("Expected %s, got: %s", expected, marshaled)
+	}
+
+	_, errs = ruleSet.Apply(ctx, `{"data": {"type": "people", "attributes": {"firstName": ""}}}`)
+	if errs == nil {
+		t.Fatal("Expected an error for an empty firstName, got: nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != "/data/attributes/firstName" {
+		t.Errorf("Expected pointer /data/attributes/firstName, got: %+v", list)
+	}
+}
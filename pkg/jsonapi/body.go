@@ -1,6 +1,7 @@
 package jsonapi
 
 import (
+	"bytes"
 	"encoding/json"
 	"reflect"
 	"strings"
@@ -17,16 +18,101 @@ type Datum[T any] struct {
 	ExtensionMembers map[string]any          `json:"-"`
 	AtMembers        map[string]any          `json:"-"`
 	Fields           ValueList               `json:"-"`
+
+	// NameTransform, when set, maps attribute names to the names written to documents, e.g. first_name to
+	// firstName (see AttributesRuleSet.WithNameTransform). Fields holds the transformed names.
+	NameTransform func(string) string `json:"-"`
+
+	// OrderedMeta, when it has members, is emitted as meta in insertion order instead of Meta.
+	OrderedMeta *OrderedMetaMap `json:"-"`
+
+	// IncludeEmptyAttributes emits an empty attributes object when Fields filters out every attribute
+	// instead of omitting the attributes member. Nil attributes are always omitted, since null is not a
+	// valid attributes value.
+	IncludeEmptyAttributes bool `json:"-"`
 }
 
 // MarshalJSON implements the json.Marshaler interface for Datum[T].
 // MarshalJSON serializes the datum; output is filtered by Fields if present and extension members are copied into the resulting JSON.
 func (d Datum[T]) MarshalJSON() ([]byte, error) {
+	if d.Fields == nil && d.NameTransform == nil && len(d.ExtensionMembers) == 0 && len(d.AtMembers) == 0 {
+		return d.marshalMembers()
+	}
+	return d.marshalMap()
+}
+
+// marshalMembers writes the members of an unfiltered datum without extension or @-members directly,
+// avoiding the intermediate map of marshalMap. Members are written in sorted key order so the output
+// is identical to marshalMap.
+func (d Datum[T]) marshalMembers() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	write := func(key string, value any) error {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.WriteString(`"` + key + `":`)
+		buf.Write(raw)
+		return nil
+	}
+
+	if !isNilAttributes(reflect.ValueOf(d.Attributes)) {
+		if err := write("attributes", d.Attributes); err != nil {
+			return nil, err
+		}
+	}
+	if d.ID != "" || d.Lid == "" {
+		if err := write("id", d.ID); err != nil {
+			return nil, err
+		}
+	}
+	if d.Lid != "" {
+		if err := write("lid", d.Lid); err != nil {
+			return nil, err
+		}
+	}
+	if len(d.Links) > 0 {
+		if err := write("links", d.Links); err != nil {
+			return nil, err
+		}
+	}
+	if d.OrderedMeta.Len() > 0 {
+		if err := write("meta", d.OrderedMeta); err != nil {
+			return nil, err
+		}
+	} else if len(d.Meta) > 0 {
+		if err := write("meta", d.Meta); err != nil {
+			return nil, err
+		}
+	}
+	if len(d.Relationships) > 0 {
+		if err := write("relationships", d.Relationships); err != nil {
+			return nil, err
+		}
+	}
+	if err := write("type", d.Type); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalMap builds the datum as a map and marshals it, applying Fields and NameTransform and copying extension
+// and @-members.
+func (d Datum[T]) marshalMap() ([]byte, error) {
 	// Create a map to hold the final JSON object
 	result := make(map[string]any)
 
-	// Add non-Attributes fields
-	result["id"] = d.ID
+	// Add non-Attributes fields; a resource identified only by lid has no id yet
+	if d.ID != "" || d.Lid == "" {
+		result["id"] = d.ID
+	}
 	result["type"] = d.Type
 	if d.Lid != "" {
 		result["lid"] = d.Lid
@@ -34,22 +120,31 @@ func (d Datum[T]) MarshalJSON() ([]byte, error) {
 	if len(d.Links) > 0 {
 		result["links"] = d.Links
 	}
-	if len(d.Meta) > 0 {
+	if d.OrderedMeta.Len() > 0 {
+		result["meta"] = d.OrderedMeta
+	} else if len(d.Meta) > 0 {
 		result["meta"] = d.Meta
 	}
 
 	// Handle Attributes field
-	if d.Fields == nil {
-		// If Fields is nil, marshal Attributes as is
-		result["attributes"] = d.Attributes
+	if d.Fields == nil && d.NameTransform == nil {
+		// If Fields is nil, marshal Attributes as is; nil attributes are omitted since null is not a valid value
+		if !isNilAttributes(reflect.ValueOf(d.Attributes)) {
+			result["attributes"] = d.Attributes
+		}
 		if len(d.Relationships) > 0 {
 			result["relationships"] = d.Relationships
 		}
 	} else {
-		// If Fields is not nil, only serialize the fields in the FieldList
+		// Otherwise serialize the attributes one by one under their document names, keeping only those in Fields
+		selected := func(name string) bool {
+			return d.Fields == nil || d.Fields.Contains(name)
+		}
 		attrMap := make(map[string]any)
 		attrValue := reflect.ValueOf(d.Attributes)
+		nilAttributes := isNilAttributes(attrValue)
 		if attrValue.Kind() == reflect.Ptr {
+			// A nil pointer has no fields to filter; Elem returns an invalid value that matches no case below.
 			attrValue = attrValue.Elem()
 		}
 
@@ -57,25 +152,32 @@ func (d Datum[T]) MarshalJSON() ([]byte, error) {
 		case reflect.Struct:
 			attrType := attrValue.Type()
 			for i := 0; i < attrType.NumField(); i++ {
-				field := attrType.Field(i)
-				fieldName := field.Tag.Get("json")
-				if fieldName == "" {
-					fieldName = field.Name
+				fieldName, omitEmpty, ok := jsonFieldName(attrType.Field(i))
+				if !ok {
+					continue
 				}
-				if d.Fields.Contains(fieldName) {
-					attrMap[fieldName] = attrValue.Field(i).Interface()
+				fieldName = d.attributeName(fieldName)
+				if !selected(fieldName) {
+					continue
 				}
+				// Zero values are dropped for omitempty fields, as encoding/json does without Fields.
+				fieldValue := attrValue.Field(i)
+				if omitEmpty && isEmptyJSONValue(fieldValue) {
+					continue
+				}
+				attrMap[fieldName] = fieldValue.Interface()
 			}
 		case reflect.Map:
 			for _, key := range attrValue.MapKeys() {
-				fieldName := key.String()
-				if d.Fields.Contains(fieldName) {
+				fieldName := d.attributeName(key.String())
+				if selected(fieldName) {
 					attrMap[fieldName] = attrValue.MapIndex(key).Interface()
 				}
 			}
 		}
 
-		if len(attrMap) > 0 {
+		// Nil attributes are omitted even with IncludeEmptyAttributes; null is not a valid attributes value.
+		if !nilAttributes && (len(attrMap) > 0 || d.IncludeEmptyAttributes || d.Fields == nil) {
 			result["attributes"] = attrMap
 		}
 
@@ -83,7 +185,7 @@ func (d Datum[T]) MarshalJSON() ([]byte, error) {
 		if len(d.Relationships) > 0 {
 			relMap := make(map[string]Relationship)
 			for relName, rel := range d.Relationships {
-				if d.Fields.Contains(relName) {
+				if selected(relName) {
 					relMap[relName] = rel
 				}
 			}
@@ -105,6 +207,60 @@ func (d Datum[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(result)
 }
 
+// isNilAttributes reports whether the attributes value is nil and would marshal as null.
+func isNilAttributes(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr, reflect.Map, reflect.Interface, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+// attributeName returns the document name of the attribute name, applying NameTransform if set.
+func (d Datum[T]) attributeName(name string) string {
+	if d.NameTransform == nil {
+		return name
+	}
+	return d.NameTransform(name)
+}
+
+// jsonFieldName returns the member name of a struct field from its json tag, falling back to the field name,
+// and whether the tag has the omitempty option. ok is false for fields tagged "-".
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty bool, ok bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, true
+}
+
+// isEmptyJSONValue reports whether v is empty in the sense of the omitempty option of encoding/json:
+// false, 0, a nil pointer or interface, or an empty array, slice, map, or string.
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Ptr:
+		return v.IsZero()
+	}
+	return false
+}
+
 // UnmarshalJSON implements the json.Unmarshaler interface for Datum[T].
 func (d *Datum[T]) UnmarshalJSON(data []byte) error {
 	// Unmarshal the data into a map of json.RawMessage
@@ -144,14 +300,18 @@ func (d *Datum[T]) UnmarshalJSON(data []byte) error {
 					return err
 				}
 
-				// If the field is "attributes", capture the fields present in the attributes JSON
-				if key == "attributes" {
-					var attrMap map[string]json.RawMessage
-					if err := json.Unmarshal(value, &attrMap); err != nil {
+				// If the field is "attributes" or "relationships", capture the fields present in the JSON
+				// so the datum marshals back with the same members
+				if key == "attributes" || key == "relationships" {
+					var fieldMap map[string]json.RawMessage
+					if err := json.Unmarshal(value, &fieldMap); err != nil {
 						return err
 					}
-					for attrKey := range attrMap {
-						attributeFields[attrKey] = true
+					for fieldKey := range fieldMap {
+						attributeFields[fieldKey] = true
+					}
+					if key == "attributes" {
+						d.IncludeEmptyAttributes = true
 					}
 				}
 			}
@@ -166,8 +326,8 @@ func (d *Datum[T]) UnmarshalJSON(data []byte) error {
 					d.AtMembers = make(map[string]any)
 				}
 				d.AtMembers[key] = rawValue
-			} else if idx := strings.Index(key, ":"); idx > 0 {
-				// Handle ExtensionMembers if the key contains a ":" and it's not at the start
+			} else if isExtensionMemberName(key) {
+				// Handle ExtensionMembers (namespace:member)
 				var rawValue any
 				if err := json.Unmarshal(value, &rawValue); err != nil {
 					return err
@@ -186,14 +346,79 @@ func (d *Datum[T]) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// DatumToTyped converts a generically validated datum into a Datum[T].
+// Attributes are converted with a JSON round-trip; all other members are preserved as-is.
+func DatumToTyped[T any](d Datum[map[string]any]) (Datum[T], error) {
+	out := Datum[T]{
+		ID:               d.ID,
+		Lid:              d.Lid,
+		Type:             d.Type,
+		Links:            d.Links,
+		Relationships:    d.Relationships,
+		Meta:             d.Meta,
+		ExtensionMembers: d.ExtensionMembers,
+		AtMembers:        d.AtMembers,
+		Fields:           d.Fields,
+		NameTransform:    d.NameTransform,
+		OrderedMeta:      d.OrderedMeta,
+
+		IncludeEmptyAttributes: d.IncludeEmptyAttributes,
+	}
+
+	if d.Attributes != nil {
+		raw, err := json.Marshal(d.Attributes)
+		if err != nil {
+			return Datum[T]{}, err
+		}
+		if err := json.Unmarshal(raw, &out.Attributes); err != nil {
+			return Datum[T]{}, err
+		}
+	}
+
+	return out, nil
+}
+
+// DatumToMap converts a typed datum into a Datum[map[string]any].
+// Attributes are converted with a JSON round-trip; all other members are preserved as-is.
+// Attributes that cannot be represented as a JSON object are left nil.
+func DatumToMap[T any](d Datum[T]) Datum[map[string]any] {
+	out := Datum[map[string]any]{
+		ID:               d.ID,
+		Lid:              d.Lid,
+		Type:             d.Type,
+		Links:            d.Links,
+		Relationships:    d.Relationships,
+		Meta:             d.Meta,
+		ExtensionMembers: d.ExtensionMembers,
+		AtMembers:        d.AtMembers,
+		Fields:           d.Fields,
+		NameTransform:    d.NameTransform,
+		OrderedMeta:      d.OrderedMeta,
+
+		IncludeEmptyAttributes: d.IncludeEmptyAttributes,
+	}
+
+	if raw, err := json.Marshal(d.Attributes); err == nil {
+		var attributes map[string]any
+		if err := json.Unmarshal(raw, &attributes); err == nil {
+			out.Attributes = attributes
+		}
+	}
+
+	return out
+}
+
 type SingleDatumEnvelope[T any] struct {
 	Data             Datum[T]       `json:"data,omitempty" validate:"data"`
 	Links            Links          `json:"links,omitempty" validate:"links"`
 	Meta             map[string]any `json:"meta,omitempty" validate:"meta"`
 	Included         []any          `json:"included,omitempty" validate:"included"`
-	JsonAPI          map[string]any `json:"jsonapi,omitempty" validate:"jsonapi"`
+	JSONAPI          *JSONAPIObject `json:"jsonapi,omitempty" validate:"jsonapi"`
 	AtMembers        map[string]any `json:"-"`
 	ExtensionMembers map[string]any `json:"-"`
+
+	// OrderedMeta, when it has members, is emitted as meta in insertion order instead of Meta.
+	OrderedMeta *OrderedMetaMap `json:"-"`
 }
 
 type DatumCollectionEnvelope[T any] struct {
@@ -201,6 +426,130 @@ type DatumCollectionEnvelope[T any] struct {
 	Links            Links          `json:"links,omitempty" validate:"links"`
 	Meta             map[string]any `json:"meta,omitempty" validate:"meta"`
 	Included         []any          `json:"included,omitempty" validate:"included"`
+	JSONAPI          *JSONAPIObject `json:"jsonapi,omitempty" validate:"jsonapi"`
 	AtMembers        map[string]any `json:"-"`
 	ExtensionMembers map[string]any `json:"-"`
+
+	// OrderedMeta, when it has members, is emitted as meta in insertion order instead of Meta.
+	OrderedMeta *OrderedMetaMap `json:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface for SingleDatumEnvelope[T].
+// Extension members and @-members are copied into the top-level document.
+func (e SingleDatumEnvelope[T]) MarshalJSON() ([]byte, error) {
+	type plain SingleDatumEnvelope[T]
+	out := plain(e)
+	var orderedMeta map[string]any
+	if e.OrderedMeta.Len() > 0 {
+		out.Meta = nil
+		orderedMeta = map[string]any{"meta": e.OrderedMeta}
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return mergeMembers(data, e.ExtensionMembers, e.AtMembers, orderedMeta)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for SingleDatumEnvelope[T].
+// Top-level extension members and @-members are collected into ExtensionMembers and AtMembers.
+func (e *SingleDatumEnvelope[T]) UnmarshalJSON(data []byte) error {
+	type plain SingleDatumEnvelope[T]
+	var out plain
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	if err := collectMembers(data, &out.AtMembers, &out.ExtensionMembers); err != nil {
+		return err
+	}
+	*e = SingleDatumEnvelope[T](out)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for DatumCollectionEnvelope[T].
+// Extension members and @-members are copied into the top-level document.
+func (e DatumCollectionEnvelope[T]) MarshalJSON() ([]byte, error) {
+	type plain DatumCollectionEnvelope[T]
+	out := plain(e)
+	var orderedMeta map[string]any
+	if e.OrderedMeta.Len() > 0 {
+		out.Meta = nil
+		orderedMeta = map[string]any{"meta": e.OrderedMeta}
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return mergeMembers(data, e.ExtensionMembers, e.AtMembers, orderedMeta)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for DatumCollectionEnvelope[T].
+// Top-level extension members and @-members are collected into ExtensionMembers and AtMembers.
+func (e *DatumCollectionEnvelope[T]) UnmarshalJSON(data []byte) error {
+	type plain DatumCollectionEnvelope[T]
+	var out plain
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	if err := collectMembers(data, &out.AtMembers, &out.ExtensionMembers); err != nil {
+		return err
+	}
+	*e = DatumCollectionEnvelope[T](out)
+	return nil
+}
+
+// mergeMembers adds the members of each map to the JSON object in data.
+func mergeMembers(data []byte, memberSets ...map[string]any) ([]byte, error) {
+	empty := true
+	for _, members := range memberSets {
+		if len(members) > 0 {
+			empty = false
+		}
+	}
+	if empty {
+		return data, nil
+	}
+
+	// Existing members are kept as raw JSON so values with their own member order are not re-sorted.
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	for _, members := range memberSets {
+		for key, value := range members {
+			raw, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = raw
+		}
+	}
+	return json.Marshal(result)
+}
+
+// collectMembers stores the @-members and extension members (namespace:member) of the JSON object in data.
+func collectMembers(data []byte, atMembers, extensionMembers *map[string]any) error {
+	var rawData map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rawData); err != nil {
+		return err
+	}
+	for key, value := range rawData {
+		var target *map[string]any
+		if strings.HasPrefix(key, "@") {
+			target = atMembers
+		} else if isExtensionMemberName(key) {
+			target = extensionMembers
+		} else {
+			continue
+		}
+		var rawValue any
+		if err := json.Unmarshal(value, &rawValue); err != nil {
+			return err
+		}
+		if *target == nil {
+			*target = make(map[string]any)
+		}
+		(*target)[key] = rawValue
+	}
+	return nil
 }
@@ -0,0 +1,78 @@
+package jsonapi
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+type marshalTestAttributes struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Views int    `json:"views"`
+}
+
+func marshalTestCollection(n int) []Datum[marshalTestAttributes] {
+	data := make([]Datum[marshalTestAttributes], n)
+	for i := range data {
+		id := strconv.Itoa(i)
+		data[i] = Datum[marshalTestAttributes]{
+			ID:         id,
+			Type:       "articles",
+			Attributes: marshalTestAttributes{Title: "Article " + id, Body: "<p>Body</p>", Views: i},
+			Links:      Links{"self": StringLink("/articles/" + id)},
+			Relationships: map[string]Relationship{
+				"author": {Data: ResourceIdentifierLinkage{Type: "people", ID: id}},
+			},
+			Meta: map[string]any{"rank": i},
+		}
+	}
+	return data
+}
+
+// Requirements:
+// - Datums without Fields, extension members, or @-members marshal identically through both paths.
+func TestDatum_MarshalMembersMatchesMap(t *testing.T) {
+	data := marshalTestCollection(3)
+	data[1].Lid, data[1].ID = "local-1", ""
+	data[2].Meta = nil
+	data[2].OrderedMeta = OrderedMeta(MetaPair{Key: "z", Value: 1}, MetaPair{Key: "a", Value: 2})
+
+	for i, d := range data {
+		fast, err := d.marshalMembers()
+		if err != nil {
+			t.Fatalf("Expected marshalMembers to succeed, got: %s", err)
+		}
+		slow, err := d.marshalMap()
+		if err != nil {
+			t.Fatalf("Expected marshalMap to succeed, got: %s", err)
+		}
+		if !bytes.Equal(fast, slow) {
+			t.Errorf("Datum %d: expected identical output\nmembers: %s\nmap:     %s", i, fast, slow)
+		}
+	}
+}
+
+func BenchmarkDatum_MarshalJSON(b *testing.B) {
+	data := marshalTestCollection(10000)
+
+	b.Run("members", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, d := range data {
+				if _, err := d.marshalMembers(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, d := range data {
+				if _, err := d.marshalMap(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -131,6 +131,21 @@ func TestMarshalJSON(t *testing.T) {
 				"type":"example"
 			}`,
 		},
+		{
+			name: "Datum[ExampleAttributes] with empty FieldList and IncludeEmptyAttributes",
+			datum: jsonapi.Datum[ExampleAttributes]{
+				ID:                     "126",
+				Type:                   "example",
+				Attributes:             exampleAttr,
+				Fields:                 jsonapi.NewFieldList(),
+				IncludeEmptyAttributes: true,
+			},
+			expected: `{
+				"id":"126",
+				"type":"example",
+				"attributes":{}
+			}`,
+		},
 		{
 			name: "Datum with Relationships and Fields filtering",
 			datum: jsonapi.Datum[ExampleAttributes]{
@@ -249,6 +264,104 @@ func TestMarshalJSON(t *testing.T) {
 	}
 }
 
+// Requirements:
+// - Field filtering matches the json tag name, ignoring tag options.
+// - Zero-valued omitempty fields are dropped even when listed in Fields.
+// - Non-zero omitempty fields and zero fields without omitempty are kept.
+func TestMarshalJSON_FieldsOmitEmpty(t *testing.T) {
+	type OmitEmptyAttributes struct {
+		Name  string `json:"name"`
+		Age   int    `json:"age,omitempty"`
+		Score int    `json:"score"`
+	}
+
+	datum := jsonapi.Datum[OmitEmptyAttributes]{
+		ID:         "1",
+		Type:       "people",
+		Attributes: OmitEmptyAttributes{Name: "Ann"},
+		Fields:     jsonapi.NewFieldList("name", "age", "score"),
+	}
+	actual, err := json.Marshal(datum)
+	if err != nil {
+		t.Fatalf("Unexpected error during marshalling: %v", err)
+	}
+	if expected := `{"id":"1","type":"people","attributes":{"name":"Ann","score":0}}`; !jsonEqual(expected, string(actual)) {
+		t.Errorf("Expected JSON: %s\nGot JSON: %s", expected, actual)
+	}
+
+	datum.Attributes.Age = 30
+	actual, err = json.Marshal(datum)
+	if err != nil {
+		t.Fatalf("Unexpected error during marshalling: %v", err)
+	}
+	if expected := `{"id":"1","type":"people","attributes":{"name":"Ann","age":30,"score":0}}`; !jsonEqual(expected, string(actual)) {
+		t.Errorf("Expected JSON: %s\nGot JSON: %s", expected, actual)
+	}
+}
+
+// Requirements:
+// - Nil pointer attributes with a field list marshal without panicking and omit attributes.
+// - With IncludeEmptyAttributes, nil pointer attributes are still omitted rather than emitted as null.
+func TestMarshalJSON_FieldsNilPointerAttributes(t *testing.T) {
+	type Attrs struct {
+		Name string `json:"name"`
+	}
+
+	datum := jsonapi.Datum[*Attrs]{
+		ID:         "1",
+		Type:       "people",
+		Attributes: nil,
+		Fields:     jsonapi.NewFieldList("name"),
+	}
+	actual, err := json.Marshal(datum)
+	if err != nil {
+		t.Fatalf("Unexpected error during marshalling: %v", err)
+	}
+	if expected := `{"id":"1","type":"people"}`; !jsonEqual(expected, string(actual)) {
+		t.Errorf("Expected JSON: %s\nGot JSON: %s", expected, actual)
+	}
+
+	datum.IncludeEmptyAttributes = true
+	actual, err = json.Marshal(datum)
+	if err != nil {
+		t.Fatalf("Unexpected error during marshalling: %v", err)
+	}
+	if expected := `{"id":"1","type":"people"}`; !jsonEqual(expected, string(actual)) {
+		t.Errorf("Expected JSON: %s\nGot JSON: %s", expected, actual)
+	}
+}
+
+// Requirements:
+// - Nil pointer and nil map attributes without a field list are omitted rather than emitted as null.
+// - Empty non-nil map attributes are still emitted.
+func TestMarshalJSON_NilAttributes(t *testing.T) {
+	type Attrs struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name     string
+		datum    any
+		expected string
+	}{
+		{"nil pointer", jsonapi.Datum[*Attrs]{ID: "1", Type: "people"}, `{"id":"1","type":"people"}`},
+		{"nil map", jsonapi.Datum[map[string]any]{ID: "1", Type: "people"}, `{"id":"1","type":"people"}`},
+		{"nil map with extension members", jsonapi.Datum[map[string]any]{ID: "1", Type: "people", ExtensionMembers: map[string]any{"ext:a": 1}}, `{"id":"1","type":"people","ext:a":1}`},
+		{"empty map", jsonapi.Datum[map[string]any]{ID: "1", Type: "people", Attributes: map[string]any{}}, `{"id":"1","type":"people","attributes":{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := json.Marshal(tt.datum)
+			if err != nil {
+				t.Fatalf("Unexpected error during marshalling: %v", err)
+			}
+			if !jsonEqual(tt.expected, string(actual)) {
+				t.Errorf("Expected JSON: %s\nGot JSON: %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
 // jsonEqual compares two JSON strings for equality regardless of formatting
 func jsonEqual(a, b string) bool {
 	var o1, o2 any
@@ -279,7 +392,9 @@ func TestDatumUnmarshalJSON(t *testing.T) {
 		"links": {"self": "http://example.com/self"},
 		"meta": {"version": "1.0"},
 		"test:customField1": "customValue1",
-		"test:customField2": 42
+		"test:customField2": 42,
+		"bad-ns:member": true,
+		"empty:": true
 	}`
 
 	var datum jsonapi.Datum[ExampleAttributes]
@@ -329,7 +444,7 @@ func TestDatumUnmarshalJSON(t *testing.T) {
 		t.Errorf("Fields do not match. Got %v, want %v", actualFields, expectedFields)
 	}
 
-	// Verify ExtensionMembers
+	// Verify ExtensionMembers; keys that are not namespace:member are not extension members
 	expectedExtensionMembers := map[string]any{
 		"test:customField1": "customValue1",
 		"test:customField2": float64(42),
@@ -338,3 +453,105 @@ func TestDatumUnmarshalJSON(t *testing.T) {
 		t.Errorf("Expected ExtensionMembers to be %+v, got %+v", expectedExtensionMembers, datum.ExtensionMembers)
 	}
 }
+
+// Requirements:
+// - Attributes are converted in both directions.
+// - ID, type, relationships, links, and meta are preserved.
+func TestDatumToTypedAndDatumToMap(t *testing.T) {
+	type ExampleAttributes struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	relationships := map[string]jsonapi.Relationship{
+		"author": {Data: jsonapi.ResourceIdentifierLinkage{Type: "people", ID: "9"}},
+	}
+
+	generic := jsonapi.Datum[map[string]any]{
+		ID:            "1",
+		Type:          "example",
+		Attributes:    map[string]any{"name": "John Doe", "age": 30},
+		Links:         jsonapi.Links{"self": jsonapi.StringLink("http://example.com/example/1")},
+		Relationships: relationships,
+		Meta:          map[string]any{"version": "1.0"},
+	}
+
+	typed, err := jsonapi.DatumToTyped[ExampleAttributes](generic)
+	if err != nil {
+		t.Fatalf("Unexpected error converting to typed datum: %v", err)
+	}
+	if typed.ID != "1" || typed.Type != "example" {
+		t.Errorf("Expected id and type to be preserved, got %q and %q", typed.ID, typed.Type)
+	}
+	if typed.Attributes != (ExampleAttributes{Name: "John Doe", Age: 30}) {
+		t.Errorf("Unexpected typed attributes: %+v", typed.Attributes)
+	}
+	if !reflect.DeepEqual(typed.Relationships, relationships) {
+		t.Errorf("Expected relationships to be preserved, got %+v", typed.Relationships)
+	}
+	if !reflect.DeepEqual(typed.Links, generic.Links) {
+		t.Errorf("Expected links to be preserved, got %+v", typed.Links)
+	}
+	if !reflect.DeepEqual(typed.Meta, generic.Meta) {
+		t.Errorf("Expected meta to be preserved, got %+v", typed.Meta)
+	}
+
+	back := jsonapi.DatumToMap(typed)
+	expectedAttributes := map[string]any{"name": "John Doe", "age": float64(30)}
+	if !reflect.DeepEqual(back.Attributes, expectedAttributes) {
+		t.Errorf("Expected attributes %+v, got %+v", expectedAttributes, back.Attributes)
+	}
+	if !reflect.DeepEqual(back.Relationships, relationships) {
+		t.Errorf("Expected relationships to be preserved, got %+v", back.Relationships)
+	}
+
+	// Attributes that do not fit the target type return an error.
+	generic.Attributes = map[string]any{"age": "thirty"}
+	if _, err := jsonapi.DatumToTyped[ExampleAttributes](generic); err == nil {
+		t.Error("Expected error for attributes that do not match the target type")
+	}
+}
+
+// Requirements:
+// - The jsonapi member is omitted when not set.
+// - Version defaults to 1.1 and empty slices are omitted.
+// - Ext, profile, and meta are emitted when set.
+func TestEnvelopeJSONAPIObject(t *testing.T) {
+	envelope := jsonapi.DatumCollectionEnvelope[map[string]any]{Data: []jsonapi.Datum[map[string]any]{}}
+	actual, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Unexpected error during marshalling: %v", err)
+	}
+	if !jsonEqual(`{"data":[]}`, string(actual)) {
+		t.Errorf("Expected jsonapi member to be omitted, got %s", actual)
+	}
+
+	envelope.JSONAPI = &jsonapi.JSONAPIObject{}
+	actual, err = json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Unexpected error during marshalling: %v", err)
+	}
+	if !jsonEqual(`{"data":[],"jsonapi":{"version":"1.1"}}`, string(actual)) {
+		t.Errorf("Expected default version, got %s", actual)
+	}
+
+	envelope.JSONAPI = &jsonapi.JSONAPIObject{
+		Version: jsonapi.Version_1_0,
+		Ext:     []string{"https://jsonapi.org/ext/atomic"},
+		Profile: []string{"https://example.com/profile"},
+		Meta:    map[string]any{"copyright": "Example"},
+	}
+	actual, err = json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Unexpected error during marshalling: %v", err)
+	}
+	expected := `{"data":[],"jsonapi":{
+		"version":"1.0",
+		"ext":["https://jsonapi.org/ext/atomic"],
+		"profile":["https://example.com/profile"],
+		"meta":{"copyright":"Example"}
+	}}`
+	if !jsonEqual(expected, string(actual)) {
+		t.Errorf("Expected JSON: %s\nGot JSON: %s", expected, actual)
+	}
+}
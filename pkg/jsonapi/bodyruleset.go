@@ -3,19 +3,35 @@ package jsonapi
 import (
 	"context"
 	"encoding/json"
+	"sort"
 
 	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
 	"proto.zip/studio/validate/pkg/rules"
 )
 
 type SingleRuleSet[T any] struct {
+	documentOptions
 	datumRuleSet *DatumRuleSet[T]
 	metaRuleSet  *rules.ObjectRuleSet[map[string]any, string, any]
+	metaHook     rules.RuleSet[map[string]any]
 	required     bool
 	errorConfig  *errors.ErrorConfig
 	rules.NoConflict[SingleDatumEnvelope[T]]
 }
 
+// documentOptions holds the document-level settings shared by SingleRuleSet and CollectionRuleSet.
+type documentOptions struct {
+	versions     []Version
+	extensions   []string
+	negotiated   []Extension
+	declared     []Extension
+	strictNames  bool
+	strictTop    bool
+	maxResources int
+	linkage      linkageMode
+}
+
 // NewSingleRuleSet returns a rule set for a single primary resource document with the given type and attributes validation.
 func NewSingleRuleSet[T any](typeName string, attributesRuleSet rules.RuleSet[T]) *SingleRuleSet[T] {
 	metaRuleSet := rules.StringMap[any]()
@@ -28,13 +44,36 @@ func NewSingleRuleSet[T any](typeName string, attributesRuleSet rules.RuleSet[T]
 // clone returns a shallow copy of the rule set for use in builder methods.
 func (ruleSet *SingleRuleSet[T]) clone() *SingleRuleSet[T] {
 	return &SingleRuleSet[T]{
-		datumRuleSet: ruleSet.datumRuleSet,
-		metaRuleSet:  ruleSet.metaRuleSet,
-		required:     ruleSet.required,
-		errorConfig:  ruleSet.errorConfig,
+		documentOptions: ruleSet.documentOptions,
+		datumRuleSet:    ruleSet.datumRuleSet,
+		metaRuleSet:     ruleSet.metaRuleSet,
+		metaHook:        ruleSet.metaHook,
+		required:        ruleSet.required,
+		errorConfig:     ruleSet.errorConfig,
 	}
 }
 
+// WithIDRule replaces the rule set used to validate the primary resource id (see DatumRuleSet.WithIDRule).
+func (ruleSet *SingleRuleSet[T]) WithIDRule(idRuleSet rules.RuleSet[string]) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithIDRule(idRuleSet)
+	return newRuleSet
+}
+
+// WithTypeResolver delegates type validation of the primary resource to resolver (see DatumRuleSet.WithTypeResolver).
+func (ruleSet *SingleRuleSet[T]) WithTypeResolver(resolver func(ctx context.Context, typeName string) bool) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithTypeResolver(resolver)
+	return newRuleSet
+}
+
+// WithTypeAliases also accepts each of typeNames as the primary resource type (see DatumRuleSet.WithTypeAliases).
+func (ruleSet *SingleRuleSet[T]) WithTypeAliases(typeNames ...string) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithTypeAliases(typeNames...)
+	return newRuleSet
+}
+
 // WithRelationship registers a relationship name and its rule set for the primary resource.
 func (ruleSet *SingleRuleSet[T]) WithRelationship(relName string, relRuleSet rules.RuleSet[Relationship]) *SingleRuleSet[T] {
 	newRuleSet := ruleSet.clone()
@@ -42,6 +81,28 @@ func (ruleSet *SingleRuleSet[T]) WithRelationship(relName string, relRuleSet rul
 	return newRuleSet
 }
 
+// WithTypedRelationship registers a relationship for the primary resource whose linkage must have the expected type.
+func (ruleSet *SingleRuleSet[T]) WithTypedRelationship(relName, expectedType string, relRuleSet rules.RuleSet[Relationship]) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithTypedRelationship(relName, expectedType, relRuleSet)
+	return newRuleSet
+}
+
+// WithRelationshipMeta validates the meta of the named relationship of the primary resource with metaRuleSet
+// (see DatumRuleSet.WithRelationshipMeta).
+func (ruleSet *SingleRuleSet[T]) WithRelationshipMeta(relName string, metaRuleSet rules.RuleSet[map[string]any]) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithRelationshipMeta(relName, metaRuleSet)
+	return newRuleSet
+}
+
+// WithRequiredRelationship marks a relationship of the primary resource as required when creating it (POST).
+func (ruleSet *SingleRuleSet[T]) WithRequiredRelationship(relName string) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithRequiredRelationship(relName)
+	return newRuleSet
+}
+
 // WithUnknownRelationships allows any relationship name with dynamic validation.
 func (ruleSet *SingleRuleSet[T]) WithUnknownRelationships() *SingleRuleSet[T] {
 	newRuleSet := ruleSet.clone()
@@ -70,6 +131,23 @@ func (ruleSet *SingleRuleSet[T]) WithDocumentMeta(key string, valueRuleSet rules
 	return newRuleSet
 }
 
+// WithDocumentMetaRuleSet validates the top-level document meta with metaRuleSet, for example to require
+// meta.requestId. The rule set also runs when meta is absent, receiving an empty map, so required keys are
+// reported at their pointer (e.g. /meta/requestId). Keys not covered by WithDocumentMeta are allowed.
+func (ruleSet *SingleRuleSet[T]) WithDocumentMetaRuleSet(metaRuleSet rules.RuleSet[map[string]any]) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.metaRuleSet = newRuleSet.metaRuleSet.WithUnknown()
+	newRuleSet.metaHook = metaRuleSet
+	return newRuleSet
+}
+
+// WithResourceMetaRuleSet validates the primary resource meta with metaRuleSet (see DatumRuleSet.WithMetaRuleSet).
+func (ruleSet *SingleRuleSet[T]) WithResourceMetaRuleSet(metaRuleSet rules.RuleSet[map[string]any]) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithMetaRuleSet(metaRuleSet)
+	return newRuleSet
+}
+
 // WithUnknownDocumentMeta allows any top-level document meta key.
 func (ruleSet *SingleRuleSet[T]) WithUnknownDocumentMeta() *SingleRuleSet[T] {
 	newRuleSet := ruleSet.clone()
@@ -77,6 +155,162 @@ func (ruleSet *SingleRuleSet[T]) WithUnknownDocumentMeta() *SingleRuleSet[T] {
 	return newRuleSet
 }
 
+// WithStrictMeta requires every key in the document, resource, and relationship meta, including nested keys,
+// to be a valid member name.
+func (ruleSet *SingleRuleSet[T]) WithStrictMeta() *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.metaRuleSet = newRuleSet.metaRuleSet.WithRule(MetaMemberNamesRule)
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithStrictMeta()
+	return newRuleSet
+}
+
+// WithClientGeneratedID sets whether clients may send an id when creating the primary resource (default false).
+func (ruleSet *SingleRuleSet[T]) WithClientGeneratedID(allowed bool) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithClientGeneratedID(allowed)
+	return newRuleSet
+}
+
+// WithIgnoredClientID accepts but discards an id on the primary resource of a POST request, recording a warning.
+func (ruleSet *SingleRuleSet[T]) WithIgnoredClientID() *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithIgnoredClientID()
+	return newRuleSet
+}
+
+// WithSupportedVersions sets the JSON:API versions accepted in the jsonapi member (default 1.0 and 1.1).
+func (ruleSet *SingleRuleSet[T]) WithSupportedVersions(versions ...Version) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.versions = append([]Version{}, versions...)
+	return newRuleSet
+}
+
+// WithRequiredExtensions requires the jsonapi member to list each extension URI in its ext array.
+// Documents without a jsonapi member are rejected when any extension is required.
+func (ruleSet *SingleRuleSet[T]) WithRequiredExtensions(uris ...string) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.extensions = append(append([]string{}, ruleSet.extensions...), uris...)
+	return newRuleSet
+}
+
+// WithNegotiatedExtensionMembers only accepts extension members (namespace:member) on the document and the
+// primary resource whose namespace was negotiated for the request (see WithExtensions).
+// exts maps supported extension URIs to their namespaces.
+func (ruleSet *SingleRuleSet[T]) WithNegotiatedExtensionMembers(exts ...Extension) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.negotiated = append([]Extension{}, exts...)
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithNegotiatedExtensionMembers(exts...)
+	return newRuleSet
+}
+
+// WithExtensions declares the extensions the document may use. Extension members (namespace:member) on
+// the document and the primary resource whose namespace is not the prefix of a declared extension are
+// rejected. With no declared extensions, any extension member is accepted.
+func (ruleSet *SingleRuleSet[T]) WithExtensions(exts ...Extension) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.declared = append([]Extension{}, exts...)
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithExtensions(exts...)
+	return newRuleSet
+}
+
+// WithStrictMemberNames requires every object key in the document, including keys nested in attributes,
+// meta, and relationships, to be a valid member name (see MemberNameRule). Errors are reported at the
+// offending key. The values of @-members are not checked.
+func (ruleSet *SingleRuleSet[T]) WithStrictMemberNames() *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.strictNames = true
+	return newRuleSet
+}
+
+// WithStrictTopLevel rejects top-level members other than data, errors, meta, links, jsonapi, included,
+// @-members, and extension members with CodeUnexpected at the member. By default they are ignored.
+func (ruleSet *SingleRuleSet[T]) WithStrictTopLevel() *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.strictTop = true
+	return newRuleSet
+}
+
+// WithMaxResources rejects documents with more than max resource objects across data and included,
+// before the resources are validated. This guards against amplification from very large compound documents.
+func (ruleSet *SingleRuleSet[T]) WithMaxResources(max int) *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.maxResources = max
+	return newRuleSet
+}
+
+// WithFullLinkage requires every included resource to be reachable from primary data, directly or through
+// the relationships of other included resources. Unlinked resources are reported at /included/N.
+func (ruleSet *SingleRuleSet[T]) WithFullLinkage() *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.linkage = linkageFull
+	return newRuleSet
+}
+
+// WithDeepLinkage checks full linkage and also requires every relationship of an included resource to
+// reference primary data or another included resource. Unresolved references are reported at the identifier.
+func (ruleSet *SingleRuleSet[T]) WithDeepLinkage() *SingleRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.linkage = linkageDeep
+	return newRuleSet
+}
+
+// jsonAPIObjectRuleSet returns the rule set for the jsonapi member using the configured versions and extensions.
+func (opts documentOptions) jsonAPIObjectRuleSet() rules.RuleSet[*JSONAPIObject] {
+	if opts.versions == nil && len(opts.extensions) == 0 {
+		return JSONAPIObjectRuleSet
+	}
+	versions := opts.versions
+	if versions == nil {
+		versions = defaultSupportedVersions
+	}
+	return newJSONAPIObjectRuleSet(versions, opts.extensions)
+}
+
+// evaluateDocument runs the checks on the decoded document that come before its resources are validated:
+// the resource limit, unknown top-level members, and member names.
+func (opts documentOptions) evaluateDocument(ctx context.Context, decodedInput any) errors.ValidationError {
+	if errs := evaluateResourceCount(ctx, decodedInput, opts.maxResources); errs != nil {
+		return errs
+	}
+	if opts.strictTop {
+		if errs := evaluateUnknownTopLevelMembers(ctx, decodedInput); errs != nil {
+			return errs
+		}
+	}
+	if opts.strictNames {
+		if errs := errors.Join(evaluateMemberNames(ctx, decodedInput)...); errs != nil {
+			return errs
+		}
+	}
+	return nil
+}
+
+// evaluateEnvelope runs the checks that come after the document has been decoded: included resources,
+// linkage, top-level extension members, and the extensions the jsonapi member must list.
+func (opts documentOptions) evaluateEnvelope(ctx context.Context, decodedInput any, extensionMembers map[string]any, jsonAPI *JSONAPIObject) errors.ValidationError {
+	if errs := evaluateIncluded(ctx, decodedInput); errs != nil {
+		return errs
+	}
+	if errs := evaluateLinkage(ctx, decodedInput, opts.linkage); errs != nil {
+		return errs
+	}
+	if opts.negotiated != nil {
+		if errs := evaluateExtensionMembers(ctx, extensionMembers, opts.negotiated); errs != nil {
+			return errs
+		}
+	}
+	if len(opts.declared) > 0 {
+		if errs := evaluateDeclaredExtensionMembers(ctx, extensionMembers, opts.declared); errs != nil {
+			return errs
+		}
+	}
+	if jsonAPI == nil && len(opts.extensions) > 0 {
+		jsonAPICtx := rulecontext.WithPathString(ctx, "jsonapi")
+		return errors.Errorf(errors.CodeRequired, jsonAPICtx, "jsonapi member required", "jsonapi member must list the required extensions %v", opts.extensions)
+	}
+	return nil
+}
+
 // WithRequired marks the primary data member as required.
 func (ruleSet *SingleRuleSet[T]) WithRequired() *SingleRuleSet[T] {
 	if ruleSet.required {
@@ -162,6 +396,9 @@ func (ruleSet *SingleRuleSet[T]) WithErrorCallback(fn errors.ErrorCallback) *Sin
 // Apply decodes and validates the input (string or map) into the output envelope.
 func (ruleSet *SingleRuleSet[T]) Apply(ctx context.Context, input any) (SingleDatumEnvelope[T], errors.ValidationError) {
 	var zero SingleDatumEnvelope[T]
+	if isOptions(ctx) {
+		return zero, nil
+	}
 	if ruleSet.errorConfig != nil {
 		ctx = errors.WithErrorConfig(ctx, ruleSet.errorConfig)
 	}
@@ -172,13 +409,26 @@ func (ruleSet *SingleRuleSet[T]) Apply(ctx context.Context, input any) (SingleDa
 	var decodedInput any
 	if inputStr, ok := input.(string); ok {
 		if err := json.Unmarshal([]byte(inputStr), &decodedInput); err != nil {
-			return zero, ToJSONAPIErrors(errors.Errorf(errors.CodeEncoding, ctx, "Invalid JSON encoding", "Body must be Json encoded"), SourcePointer)
+			return zero, errors.Join(&jsonAPIErrorWrapper{err: MalformedJSONError([]byte(inputStr))})
 		}
 		input = decodedInput
 	} else if inputMap, ok := input.(map[string]any); ok {
 		decodedInput = inputMap
 	}
 
+	if errs := evaluateCanceled(ctx); errs != nil {
+		return zero, errs
+	}
+	if errs := evaluateTopLevelMembers(ctx, decodedInput); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+	if errs := evaluateSingleData(ctx, decodedInput); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+	if errs := ruleSet.evaluateDocument(ctx, decodedInput); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+
 	bodyValidator := rules.Struct[SingleDatumEnvelope[T]]()
 	// Allow data to be nil for meta-only documents - wrap to handle nil
 	dataRuleSet := rules.Interface[Datum[T]]().WithCast(func(ctx context.Context, value any) (Datum[T], errors.ValidationError) {
@@ -190,38 +440,147 @@ func (ruleSet *SingleRuleSet[T]) Apply(ctx context.Context, input any) (SingleDa
 	})
 	bodyValidator = bodyValidator.WithKey("data", dataRuleSet.Any())
 	bodyValidator = bodyValidator.WithKey("meta", ruleSet.metaRuleSet.Any())
-	bodyValidator = bodyValidator.WithKey("links", LinksRuleSet.Any())
+	bodyValidator = bodyValidator.WithKey("links", DocumentLinksRuleSet.Any())
 	bodyValidator = bodyValidator.WithKey("included", IncludedRuleSet.Any())
 	// Allow jsonapi as a top-level member (JSON:API spec allows this)
-	bodyValidator = bodyValidator.WithKey("jsonapi", rules.StringMap[any]().WithUnknown().Any())
+	bodyValidator = bodyValidator.WithKey("jsonapi", ruleSet.jsonAPIObjectRuleSet().Any())
 
 	bodyValidator = bodyValidator.WithDynamicBucket(atMembersKeyRule, "AtMembers")
 	bodyValidator = bodyValidator.WithDynamicBucket(extKeyRule, "ExtensionMembers")
 
 	envelope, err := bodyValidator.Apply(ctx, input)
+	if errs := evaluateCanceled(ctx); errs != nil {
+		// Report only the cancellation, not the errors of the elements skipped because of it.
+		return zero, errs
+	}
 	if err != nil {
 		return zero, ToJSONAPIErrors(err, SourcePointer)
 	}
+	if errs := evaluateMetaHook(ctx, ruleSet.metaHook, envelope.Meta); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+	if errs := ruleSet.evaluateEnvelope(ctx, decodedInput, envelope.ExtensionMembers, envelope.JSONAPI); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
 
-	if decodedInput != nil {
-		inputMap := decodedInput.(map[string]any)
-		data, ok := inputMap["data"]
-		if ok && data != nil {
-			dataMap := data.(map[string]any)
-			attributes, ok := dataMap["attributes"].(map[string]any)
-			if ok {
-				fields := make(fieldListMap)
-				for key := range attributes {
-					fields[key] = true
-				}
-				envelope.Data.Fields = fields
+	if inputMap, ok := decodedInput.(map[string]any); ok {
+		dataMap, _ := inputMap["data"].(map[string]any)
+		if attributes, ok := dataMap["attributes"].(map[string]any); ok {
+			// Fields holds the names used in the document, which NameTransform writes when marshaling.
+			fields := make(fieldListMap)
+			for key := range attributes {
+				fields[key] = true
 			}
+			envelope.Data.Fields = fields
 		}
 	}
 
 	return envelope, nil
 }
 
+// evaluateTopLevelMembers checks the top-level members required by the spec: a document must contain
+// at least one of data, errors, or meta, and data and errors must not coexist.
+func evaluateTopLevelMembers(ctx context.Context, decodedInput any) errors.ValidationError {
+	inputMap, ok := decodedInput.(map[string]any)
+	if !ok {
+		return nil
+	}
+	_, hasData := inputMap["data"]
+	_, hasErrors := inputMap["errors"]
+	_, hasMeta := inputMap["meta"]
+
+	switch {
+	case hasData && hasErrors:
+		errorsCtx := rulecontext.WithPathString(ctx, "errors")
+		return errors.Errorf(errors.CodeNotAllowed, errorsCtx, "data and errors coexist", "A document must not contain both data and errors")
+	case !hasData && !hasErrors && !hasMeta:
+		return errors.Errorf(errors.CodeRequired, ctx, "top-level member required", "A document must contain at least one of data, errors, or meta")
+	}
+	return nil
+}
+
+// evaluateSingleData returns a CodeType error at /data when the primary data of a single resource document
+// is present but neither a resource object nor null, e.g. an array.
+func evaluateSingleData(ctx context.Context, decodedInput any) errors.ValidationError {
+	inputMap, ok := decodedInput.(map[string]any)
+	if !ok {
+		return nil
+	}
+	switch inputMap["data"].(type) {
+	case nil, map[string]any:
+		return nil
+	}
+	dataCtx := rulecontext.WithPathString(ctx, "data")
+	return errors.Errorf(errors.CodeType, dataCtx, "resource object expected", "Primary data must be a resource object or null")
+}
+
+// topLevelMembers are the top-level members defined by the spec.
+var topLevelMembers = map[string]bool{"data": true, "errors": true, "meta": true, "links": true, "jsonapi": true, "included": true}
+
+// evaluateUnknownTopLevelMembers returns a CodeUnexpected error for each top-level member that is not
+// defined by the spec, an @-member, or an extension member, in member name order.
+func evaluateUnknownTopLevelMembers(ctx context.Context, decodedInput any) errors.ValidationError {
+	inputMap, ok := decodedInput.(map[string]any)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(inputMap))
+	for key := range inputMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var allErrors []error
+	for _, key := range keys {
+		if topLevelMembers[key] || atMembersKeyRule.Evaluate(ctx, key) == nil || extKeyRule.Evaluate(ctx, key) == nil {
+			continue
+		}
+		keyCtx := rulecontext.WithPathString(ctx, key)
+		allErrors = append(allErrors, errors.Errorf(errors.CodeUnexpected, keyCtx, "unexpected member", "Unexpected top-level member %q", key))
+	}
+	return errors.Join(allErrors...)
+}
+
+// evaluateIncluded rejects an included member in a document without primary data,
+// since there is nothing for the included resources to link to.
+func evaluateIncluded(ctx context.Context, decodedInput any) errors.ValidationError {
+	inputMap, ok := decodedInput.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if _, ok := inputMap["included"]; !ok {
+		return nil
+	}
+	if data, ok := inputMap["data"]; ok && data != nil {
+		return nil
+	}
+	includedCtx := rulecontext.WithPathString(ctx, "included")
+	return errors.Errorf(errors.CodeUnexpected, includedCtx, "included without primary data", "included is only allowed when the document has primary data")
+}
+
+// evaluateResourceCount returns an error at the document root when data and included together hold
+// more than max resource objects. A max of zero or less disables the check.
+func evaluateResourceCount(ctx context.Context, decodedInput any, max int) errors.ValidationError {
+	inputMap, ok := decodedInput.(map[string]any)
+	if max <= 0 || !ok {
+		return nil
+	}
+	count := 0
+	switch data := inputMap["data"].(type) {
+	case []any:
+		count += len(data)
+	case map[string]any:
+		count++
+	}
+	if included, ok := inputMap["included"].([]any); ok {
+		count += len(included)
+	}
+	if count > max {
+		return errors.Errorf(errors.CodeMax, ctx, "too many resources", "Document contains %d resources, the maximum is %d", count, max)
+	}
+	return nil
+}
+
 // Evaluate validates a SingleDatumEnvelope value and returns any validation errors.
 func (ruleSet *SingleRuleSet[T]) Evaluate(ctx context.Context, value SingleDatumEnvelope[T]) errors.ValidationError {
 	_, err := ruleSet.Apply(ctx, value)
@@ -2,6 +2,8 @@ package jsonapi_test
 
 import (
 	"context"
+	"encoding/json"
+	"regexp"
 	"testing"
 
 	"proto.zip/studio/jsonapi/pkg/jsonapi"
@@ -218,6 +220,44 @@ func TestSingleRuleSet_WithRelationship(t *testing.T) {
 	}
 }
 
+// Requirements:
+// - An empty relationship object errors with CodeRequired at the relationship pointer.
+// - Relationships with only links, only meta, or null data pass, and links are kept.
+func TestSingleRuleSet_RelationshipRequiresMember(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithRelationship("author", jsonapi.RelationshipRuleSet)
+	ctx := context.Background()
+
+	_, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"author": {}}}}`)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Code != string(errors.CodeRequired) {
+		t.Errorf("Expected code %s, got %s", errors.CodeRequired, list[0].Code)
+	}
+	if list[0].Source == nil || list[0].Source.Pointer != "/data/relationships/author" {
+		t.Errorf("Expected pointer /data/relationships/author, got %+v", list[0].Source)
+	}
+
+	out, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"author": {"links": {"related": "/articles/1/author"}}}}}`)
+	if errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	} else if link := out.Data.Relationships["author"].Links["related"]; link == nil || link.Href() != "/articles/1/author" {
+		t.Errorf("Expected the related link to be kept, got %+v", out.Data.Relationships["author"].Links)
+	}
+
+	for _, rel := range []string{`{"meta": {"count": 1}}`, `{"data": null}`} {
+		body := `{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"author": ` + rel + `}}}`
+		if _, errs := ruleSet.Apply(ctx, body); errs != nil {
+			t.Errorf("Expected errors to be nil for %s, got: %s", rel, errs)
+		}
+	}
+}
+
 func TestSingleRuleSet_WithRequired(t *testing.T) {
 	type testDatum struct {
 		Name string
@@ -365,3 +405,1121 @@ func TestSingleRuleSet_WithMetaWithDocumentMetaWithNil(t *testing.T) {
 		t.Fatalf("Apply: %s", errs)
 	}
 }
+
+// Requirements:
+// - A POST body missing a required relationship errors with CodeRequired at /data/relationships/<name>.
+// - A POST body with null data for a required relationship errors.
+// - A POST body with the relationship present passes.
+// - Other methods do not require the relationship.
+func TestSingleRuleSet_WithRequiredRelationship(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithRelationship("author", jsonapi.RelationshipRuleSet).
+		WithRequiredRelationship("author")
+
+	ctx := jsonapi.WithMethod(context.Background(), "POST")
+
+	present := `{"data": {"type": "articles", "attributes": {}, "relationships": {"author": {"data": {"type": "people", "id": "9"}}}}}`
+	if _, errs := ruleSet.Apply(ctx, present); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	for name, body := range map[string]string{
+		"missing": `{"data": {"type": "articles", "attributes": {}}}`,
+		"null":    `{"data": {"type": "articles", "attributes": {}, "relationships": {"author": {"data": null}}}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, errs := ruleSet.Apply(ctx, body)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d", len(list))
+			}
+			if list[0].Code != string(errors.CodeRequired) {
+				t.Errorf("Expected code %s, got %s", errors.CodeRequired, list[0].Code)
+			}
+			if list[0].Source == nil || list[0].Source.Pointer != "/data/relationships/author" {
+				t.Errorf("Expected pointer /data/relationships/author, got %+v", list[0].Source)
+			}
+		})
+	}
+
+	patchCtx := jsonapi.WithId(jsonapi.WithMethod(context.Background(), "PATCH"), "1")
+	if _, errs := ruleSet.Apply(patchCtx, `{"data": {"type": "articles", "id": "1", "attributes": {}}}`); errs != nil {
+		t.Errorf("Expected PATCH without relationship to pass, got: %s", errs)
+	}
+}
+
+// Requirements:
+// - Known versions and URI arrays are accepted.
+// - Unknown versions error at /jsonapi/version.
+// - Non-URI ext values are rejected.
+// - WithSupportedVersions restricts the accepted versions.
+// - WithRequiredExtensions rejects documents that omit a mandated extension.
+func TestSingleRuleSet_JSONAPIObject(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	ctx := context.Background()
+
+	valid := `{"jsonapi": {"version": "1.1", "ext": ["https://jsonapi.org/ext/atomic"], "profile": ["https://example.com/profile"]}, "data": {"type": "articles", "id": "1", "attributes": {}}}`
+	out, errs := ruleSet.Apply(ctx, valid)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if out.JSONAPI == nil || len(out.JSONAPI.Ext) != 1 || out.JSONAPI.Ext[0] != "https://jsonapi.org/ext/atomic" {
+		t.Errorf("Expected ext to be decoded, got %+v", out.JSONAPI)
+	}
+
+	_, errs = ruleSet.Apply(ctx, `{"jsonapi": {"version": "2.0"}, "data": {"type": "articles", "id": "1", "attributes": {}}}`)
+	if errs == nil {
+		t.Fatal("Expected error for unknown version")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != "/jsonapi/version" {
+		t.Errorf("Expected a single error at /jsonapi/version, got %+v", list)
+	}
+
+	if _, errs := ruleSet.Apply(ctx, `{"jsonapi": {"ext": ["not a uri"]}, "data": {"type": "articles", "id": "1", "attributes": {}}}`); errs == nil {
+		t.Error("Expected error for ext value that is not a URI")
+	}
+
+	if _, errs := ruleSet.WithSupportedVersions(jsonapi.Version_1_1).Apply(ctx, `{"jsonapi": {"version": "1.0"}, "data": {"type": "articles", "id": "1", "attributes": {}}}`); errs == nil {
+		t.Error("Expected error for version excluded by WithSupportedVersions")
+	}
+
+	required := ruleSet.WithRequiredExtensions("https://jsonapi.org/ext/atomic")
+	if _, errs := required.Apply(ctx, valid); errs != nil {
+		t.Errorf("Expected document listing the required extension to pass, got: %s", errs)
+	}
+	if _, errs := required.Apply(ctx, `{"jsonapi": {"version": "1.1"}, "data": {"type": "articles", "id": "1", "attributes": {}}}`); errs == nil {
+		t.Error("Expected error when the required extension is not listed")
+	}
+	if _, errs := required.Apply(ctx, `{"data": {"type": "articles", "id": "1", "attributes": {}}}`); errs == nil {
+		t.Error("Expected error when the jsonapi member is missing")
+	}
+}
+
+// Requirements:
+// - Extension members are accepted by default without negotiation.
+// - In negotiated mode, members of a negotiated namespace are accepted.
+// - In negotiated mode, members of an unnegotiated namespace error at the member.
+// - A negotiated URI missing from the supported list resolves to its official namespace.
+func TestSingleRuleSet_WithNegotiatedExtensionMembers(t *testing.T) {
+	versionExt := jsonapi.Extension{URI: "https://jsonapi.org/ext/version", Prefix: "version"}
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	body := `{"data": {"type": "articles", "id": "1", "attributes": {}, "version:id": "42"}}`
+
+	if _, errs := ruleSet.Apply(context.Background(), body); errs != nil {
+		t.Fatalf("Expected extension members to be accepted by default, got: %s", errs)
+	}
+
+	strict := ruleSet.WithNegotiatedExtensionMembers(versionExt)
+
+	// Negotiated via Content-Type ext (URI only, namespace resolved from the supported extension).
+	negotiatedCtx := jsonapi.WithExtensions(context.Background(), jsonapi.Extension{URI: versionExt.URI})
+	out, errs := strict.Apply(negotiatedCtx, body)
+	if errs != nil {
+		t.Fatalf("Expected negotiated namespace to be accepted, got: %s", errs)
+	}
+	if out.Data.ExtensionMembers["version:id"] != "42" {
+		t.Errorf("Expected extension member to be captured, got %v", out.Data.ExtensionMembers)
+	}
+
+	_, errs = strict.Apply(context.Background(), body)
+	if errs == nil {
+		t.Fatal("Expected unnegotiated namespace to be rejected")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != "/data/version:id" {
+		t.Errorf("Expected a single error at /data/version:id, got %+v", list)
+	}
+
+	_, errs = strict.Apply(negotiatedCtx, `{"data": {"type": "articles", "id": "1", "attributes": {}}, "other:member": true}`)
+	if errs == nil {
+		t.Fatal("Expected unnegotiated top-level namespace to be rejected")
+	}
+
+	if _, errs := ruleSet.WithNegotiatedExtensionMembers().Apply(negotiatedCtx, body); errs != nil {
+		t.Errorf("Expected official namespace to be accepted without a supported list, got: %s", errs)
+	}
+}
+
+// Requirements:
+// - included with primary data passes.
+// - included with null or absent data errors at /included.
+func TestSingleRuleSet_IncludedRequiresPrimaryData(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithUnknownDocumentMeta()
+	ctx := context.Background()
+
+	valid := `{"data": {"type": "articles", "id": "1", "attributes": {}}, "included": [{"type": "people", "id": "9"}]}`
+	if _, errs := ruleSet.Apply(ctx, valid); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	for name, body := range map[string]string{
+		"null":   `{"data": null, "included": [{"type": "people", "id": "9"}]}`,
+		"absent": `{"meta": {"count": 0}, "included": [{"type": "people", "id": "9"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, errs := ruleSet.Apply(ctx, body)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != "/included" {
+				t.Errorf("Expected a single error at /included, got %+v", list)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - Linkage with the expected type passes.
+// - Single linkage with another type errors with CodeNotAllowed at /data/relationships/<name>/data/type.
+// - Every element of a to-many linkage is checked.
+func TestSingleRuleSet_WithTypedRelationship(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithTypedRelationship("author", "people", jsonapi.RelationshipRuleSet).
+		WithTypedRelationship("tags", "tags", jsonapi.RelationshipRuleSet)
+	ctx := context.Background()
+
+	valid := `{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {
+		"author": {"data": {"type": "people", "id": "9"}},
+		"tags": {"data": [{"type": "tags", "id": "1"}, {"type": "tags", "id": "2"}]}
+	}}}`
+	if _, errs := ruleSet.Apply(ctx, valid); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		pointer string
+	}{
+		{
+			"single",
+			`{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"author": {"data": {"type": "comments", "id": "9"}}}}}`,
+			"/data/relationships/author/data/type",
+		},
+		{
+			"collection",
+			`{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"tags": {"data": [{"type": "tags", "id": "1"}, {"type": "people", "id": "2"}]}}}}`,
+			"/data/relationships/tags/data/1/type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := ruleSet.Apply(ctx, tt.body)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d", len(list))
+			}
+			if list[0].Code != string(errors.CodeNotAllowed) {
+				t.Errorf("Expected code %s, got %s", errors.CodeNotAllowed, list[0].Code)
+			}
+			if list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+				t.Errorf("Expected pointer %s, got %+v", tt.pointer, list[0].Source)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - Types accepted by the resolver pass and are kept on the decoded datum.
+// - Types rejected by the resolver error at /data/type.
+func TestSingleRuleSet_WithTypeResolver(t *testing.T) {
+	known := map[string]bool{"articles": true, "people": true}
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithTypeResolver(func(ctx context.Context, typeName string) bool {
+			return known[typeName]
+		})
+	ctx := context.Background()
+
+	out, errs := ruleSet.Apply(ctx, `{"data": {"type": "people", "id": "1", "attributes": {}}}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if out.Data.Type != "people" {
+		t.Errorf("Expected type people, got %q", out.Data.Type)
+	}
+
+	_, errs = ruleSet.Apply(ctx, `{"data": {"type": "comments", "id": "1", "attributes": {}}}`)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Code != string(errors.CodeNotAllowed) {
+		t.Errorf("Expected code %s, got %s", errors.CodeNotAllowed, list[0].Code)
+	}
+	if list[0].Source == nil || list[0].Source.Pointer != "/data/type" {
+		t.Errorf("Expected pointer /data/type, got %+v", list[0].Source)
+	}
+}
+
+// Requirements:
+// - WithIDRule replaces the default id validation.
+// - An id that fails the rule errors with the rule's code at /data/id.
+func TestSingleRuleSet_WithIDRule(t *testing.T) {
+	uuidRule := rules.String().WithRegexp(regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`), "")
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithIDRule(uuidRule)
+	ctx := context.Background()
+
+	if _, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "id": "3f2b8c1e-4d5a-4e6f-9a0b-1c2d3e4f5a6b", "attributes": {}}}`); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	_, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "id": "abc", "attributes": {}}}`)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Code != string(errors.CodePattern) {
+		t.Errorf("Expected code %s, got %s", errors.CodePattern, list[0].Code)
+	}
+	if list[0].Source == nil || list[0].Source.Pointer != "/data/id" {
+		t.Errorf("Expected pointer /data/id, got %+v", list[0].Source)
+	}
+}
+
+// Requirements:
+// - WithStrictMeta rejects a nested meta key with a reserved character at its pointer.
+// - Valid nested keys pass.
+func TestSingleRuleSet_WithStrictMeta(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithUnknownDocumentMeta().
+		WithStrictMeta()
+	ctx := context.Background()
+
+	if _, errs := ruleSet.Apply(ctx, `{"meta": {"a": {"b": [{"c": 1}]}}}`); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	_, errs := ruleSet.Apply(ctx, `{"meta": {"a": {"b.c": 1}}}`)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Source == nil || list[0].Source.Pointer != "/meta/a/b.c" {
+		t.Errorf("Expected pointer /meta/a/b.c, got %+v", list[0].Source)
+	}
+}
+
+// Requirements:
+// - A document meta rule set requiring requestId errors with CodeRequired at /meta/requestId when absent.
+// - The same applies when the meta object is missing entirely.
+// - A resource meta rule set reports errors at /data/meta/<key>.
+func TestSingleRuleSet_MetaRuleSets(t *testing.T) {
+	requireRequestID := rules.StringMap[any]().
+		WithKey("requestId", rules.String().WithRequired().Any()).
+		WithUnknown()
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithDocumentMetaRuleSet(requireRequestID).
+		WithResourceMetaRuleSet(requireRequestID)
+	ctx := context.Background()
+
+	valid := `{"meta": {"requestId": "r1", "other": true}, "data": {"type": "articles", "id": "1", "attributes": {}, "meta": {"requestId": "r2"}}}`
+	if _, errs := ruleSet.Apply(ctx, valid); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		pointer string
+	}{
+		{"missing key", `{"meta": {"other": true}, "data": {"type": "articles", "id": "1", "attributes": {}, "meta": {"requestId": "r2"}}}`, "/meta/requestId"},
+		{"missing meta", `{"data": {"type": "articles", "id": "1", "attributes": {}, "meta": {"requestId": "r2"}}}`, "/meta/requestId"},
+		{"resource meta", `{"meta": {"requestId": "r1"}, "data": {"type": "articles", "id": "1", "attributes": {}}}`, "/data/meta/requestId"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := ruleSet.Apply(ctx, tt.body)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d", len(list))
+			}
+			if list[0].Code != string(errors.CodeRequired) {
+				t.Errorf("Expected code %s, got %s", errors.CodeRequired, list[0].Code)
+			}
+			if list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+				t.Errorf("Expected pointer %s, got %+v", tt.pointer, list[0].Source)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - A relationship meta rule set requiring count errors with CodeRequired at /data/relationships/comments/meta/count.
+// - The same applies when the relationship has no meta.
+// - WithStrictMeta checks relationship meta member names.
+func TestSingleRuleSet_WithRelationshipMeta(t *testing.T) {
+	requireCount := rules.StringMap[any]().
+		WithKey("count", rules.Int().WithRequired().Any()).
+		WithUnknown()
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithRelationship("comments", jsonapi.RelationshipRuleSet).
+		WithRelationshipMeta("comments", requireCount).
+		WithStrictMeta()
+	ctx := context.Background()
+
+	valid := `{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"comments": {"data": [], "meta": {"count": 0}}}}}`
+	if _, errs := ruleSet.Apply(ctx, valid); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		code    errors.ErrorCode
+		pointer string
+	}{
+		{"missing key", `{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"comments": {"data": [], "meta": {"total": 1}}}}}`, errors.CodeRequired, "/data/relationships/comments/meta/count"},
+		{"missing meta", `{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"comments": {"data": []}}}}`, errors.CodeRequired, "/data/relationships/comments/meta/count"},
+		{"member name", `{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"comments": {"data": [], "meta": {"count": 1, "a.b": 1}}}}}`, errors.CodeUnexpected, "/data/relationships/comments/meta/a.b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := ruleSet.Apply(ctx, tt.body)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d", len(list))
+			}
+			if list[0].Code != string(tt.code) {
+				t.Errorf("Expected code %s, got %s", tt.code, list[0].Code)
+			}
+			if list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+				t.Errorf("Expected pointer %s, got %+v", tt.pointer, list[0].Source)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - A document with both data and errors errors with CodeNotAllowed at /errors.
+// - A document without data, errors, or meta errors with CodeRequired.
+// - Data-only and meta-only documents pass.
+func TestSingleRuleSet_TopLevelMembers(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithUnknownDocumentMeta()
+	ctx := context.Background()
+
+	for _, body := range []string{
+		`{"data": {"type": "articles", "id": "1", "attributes": {}}}`,
+		`{"meta": {"copyright": "Example Corp."}}`,
+	} {
+		if _, errs := ruleSet.Apply(ctx, body); errs != nil {
+			t.Errorf("Expected %s to pass, got: %s", body, errs)
+		}
+	}
+
+	tests := []struct {
+		name string
+		body string
+		code errors.ErrorCode
+	}{
+		{"data and errors", `{"data": {"type": "articles", "id": "1", "attributes": {}}, "errors": [{"status": "400"}]}`, errors.CodeNotAllowed},
+		{"no top-level member", `{"links": {"self": "/articles/1"}}`, errors.CodeRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := ruleSet.Apply(ctx, tt.body)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d", len(list))
+			}
+			if list[0].Code != string(tt.code) {
+				t.Errorf("Expected code %s, got %s", tt.code, list[0].Code)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - A resource object with string and object links passes and keeps them.
+// - A link that is neither a string, object, nor null errors at /data/links/<name>.
+func TestSingleRuleSet_ResourceLinks(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	ctx := context.Background()
+
+	valid := `{"data": {"type": "articles", "id": "1", "attributes": {}, "links": {"self": "/articles/1", "related": {"href": "/articles/1/author"}}}}`
+	out, errs := ruleSet.Apply(ctx, valid)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if out.Data.Links.Self() != "/articles/1" || out.Data.Links.Related() != "/articles/1/author" {
+		t.Errorf("Expected links to be decoded, got %+v", out.Data.Links)
+	}
+
+	_, errs = ruleSet.Apply(ctx, `{"data": {"type": "articles", "id": "1", "attributes": {}, "links": {"self": 12345}}}`)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Source == nil || list[0].Source.Pointer != "/data/links/self" {
+		t.Errorf("Expected pointer /data/links/self, got %+v", list[0].Source)
+	}
+}
+
+// Requirements:
+// - String, object, and null links in one resource all pass validation.
+// - Each link keeps its concrete type: StringLink, *FullLink, or NilLink.
+// - Marshaling the result reproduces the original links member.
+func TestSingleRuleSet_MixedLinksRoundTrip(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	links := `{"self": "/articles/1", "related": {"href": "/articles/1/author", "title": "Author", "meta": {"count": 1}}, "describedby": null}`
+	input := `{"data": {"type": "articles", "id": "1", "attributes": {}, "links": ` + links + `}}`
+
+	out, errs := ruleSet.Apply(context.Background(), input)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if _, ok := out.Data.Links["self"].(jsonapi.StringLink); !ok {
+		t.Errorf("Expected self to be a StringLink, got %T", out.Data.Links["self"])
+	}
+	if _, ok := out.Data.Links["related"].(*jsonapi.FullLink); !ok {
+		t.Errorf("Expected related to be a *FullLink, got %T", out.Data.Links["related"])
+	}
+	if _, ok := out.Data.Links["describedby"].(jsonapi.NilLink); !ok {
+		t.Errorf("Expected describedby to be a NilLink, got %T", out.Data.Links["describedby"])
+	}
+
+	output, err := json.Marshal(out.Data.Links)
+	if err != nil {
+		t.Fatalf("Expected marshal error to be nil, got: %s", err)
+	}
+	if !jsonEqual(links, string(output)) {
+		t.Errorf("Expected links %s, got %s", links, output)
+	}
+
+	document, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Expected marshal error to be nil, got: %s", err)
+	}
+	if _, errs := ruleSet.Apply(context.Background(), string(document)); errs != nil {
+		t.Errorf("Expected the marshaled document to validate, got: %s", errs)
+	}
+}
+
+// Requirements:
+// - A type with reserved characters errors with CodeUnexpected at /data/type.
+// - The format check applies with and without a type resolver.
+// - A malformed type in the included array errors at /included/<n>/type.
+func TestSingleRuleSet_TypeMemberName(t *testing.T) {
+	attributes := rules.StringMap[any]().WithUnknown()
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		ruleSet *jsonapi.SingleRuleSet[map[string]any]
+		input   string
+		pointer string
+	}{
+		{
+			"reserved character",
+			jsonapi.NewSingleRuleSet[map[string]any]("articles", attributes),
+			`{"data": {"type": "art/icles", "id": "1", "attributes": {}}}`,
+			"/data/type",
+		},
+		{
+			"reserved character with resolver",
+			jsonapi.NewSingleRuleSet[map[string]any]("articles", attributes).
+				WithTypeResolver(func(ctx context.Context, typeName string) bool { return true }),
+			`{"data": {"type": "people!", "id": "1", "attributes": {}}}`,
+			"/data/type",
+		},
+		{
+			"included",
+			jsonapi.NewSingleRuleSet[map[string]any]("articles", attributes),
+			`{"data": {"type": "articles", "id": "1", "attributes": {}}, "included": [{"type": "people", "id": "9"}, {"type": "comm ents", "id": "5"}]}`,
+			"/included/1/type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := tt.ruleSet.Apply(ctx, tt.input)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d: %+v", len(list), list)
+			}
+			if list[0].Code != string(errors.CodeUnexpected) {
+				t.Errorf("Expected code %s, got %s", errors.CodeUnexpected, list[0].Code)
+			}
+			if list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+				t.Errorf("Expected pointer %s, got %+v", tt.pointer, list[0].Source)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - Without declared extensions, any extension member is accepted.
+// - foo:bar errors with CodeUnexpected on the resource and the document when only atomic is declared.
+// - foo:bar is accepted when foo is declared.
+func TestSingleRuleSet_WithExtensions(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	atomic := jsonapi.Extension{URI: "https://jsonapi.org/ext/atomic", Prefix: "atomic"}
+	foo := jsonapi.Extension{URI: "https://example.com/ext/foo", Prefix: "foo"}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		input   string
+		pointer string
+	}{
+		{"resource", `{"data": {"type": "articles", "id": "1", "attributes": {}, "foo:bar": 1}}`, "/data/foo:bar"},
+		{"document", `{"data": {"type": "articles", "id": "1", "attributes": {}}, "foo:bar": 1}`, "/foo:bar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, errs := ruleSet.Apply(ctx, tt.input); errs != nil {
+				t.Errorf("Expected errors to be nil without declared extensions, got: %s", errs)
+			}
+
+			_, errs := ruleSet.WithExtensions(atomic).Apply(ctx, tt.input)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d", len(list))
+			}
+			if list[0].Code != string(errors.CodeUnexpected) {
+				t.Errorf("Expected code %s, got %s", errors.CodeUnexpected, list[0].Code)
+			}
+			if list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+				t.Errorf("Expected pointer %s, got %+v", tt.pointer, list[0].Source)
+			}
+
+			if _, errs := ruleSet.WithExtensions(atomic, foo).Apply(ctx, tt.input); errs != nil {
+				t.Errorf("Expected errors to be nil with foo declared, got: %s", errs)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - Multiple attribute errors are returned sorted by source pointer.
+// - The order is the same on every run.
+func TestSingleRuleSet_DeterministicErrorOrder(t *testing.T) {
+	attributes := rules.StringMap[any]().
+		WithKey("title", rules.String().WithMinLen(3).Any()).
+		WithKey("body", rules.String().WithMinLen(3).Any()).
+		WithKey("author", rules.String().WithMinLen(3).Any()).
+		WithKey("category", rules.String().WithMinLen(3).Any())
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", attributes)
+	ctx := context.Background()
+	input := `{"data": {"type": "articles", "id": "1", "attributes": {"title": "a", "body": "b", "author": "c", "category": "d"}}}`
+	want := []string{"/data/attributes/author", "/data/attributes/body", "/data/attributes/category", "/data/attributes/title"}
+
+	for run := 0; run < 20; run++ {
+		_, errs := ruleSet.Apply(ctx, input)
+		if errs == nil {
+			t.Fatal("Expected errors to not be nil")
+		}
+		list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+		if len(list) != len(want) {
+			t.Fatalf("Expected %d errors, got %d", len(want), len(list))
+		}
+		for i, e := range list {
+			if e.Source == nil || e.Source.Pointer != want[i] {
+				t.Fatalf("run %d: errors[%d]: expected pointer %s, got %+v", run, i, want[i], e.Source)
+			}
+		}
+	}
+}
+
+// Requirements:
+// - WithStrictMemberNames rejects invalid keys anywhere in the document at their pointer.
+// - @-members, their values, and namespace:member names are accepted.
+func TestSingleRuleSet_WithStrictMemberNames(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithUnknownDocumentMeta().
+		WithUnknownRelationships().
+		WithStrictMemberNames()
+	ctx := context.Background()
+
+	valid := `{"@context": {"any key!": 1}, "data": {"type": "articles", "id": "1", "attributes": {"address": {"street": "Main"}}}, "meta": {"ext:count": 1}}`
+	if _, errs := ruleSet.Apply(ctx, valid); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	tests := []struct {
+		input   string
+		pointer string
+	}{
+		{`{"data": {"type": "articles", "id": "1", "attributes": {"address": {"street name": "Main"}}}}`, "/data/attributes/address/street name"},
+		{`{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"author": {"data": null, "meta": {"a.b": 1}}}}}`, "/data/relationships/author/meta/a.b"},
+		{`{"meta": {"list": [{"a+b": 1}]}}`, "/meta/list/0/a+b"},
+	}
+	for _, tt := range tests {
+		_, errs := ruleSet.Apply(ctx, tt.input)
+		if errs == nil {
+			t.Errorf("Expected an error for %s", tt.input)
+			continue
+		}
+		list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+		if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+			t.Errorf("Expected one error at %q, got: %+v", tt.pointer, list)
+		}
+	}
+}
+
+// Requirements:
+// - Documents within the resource cap are accepted.
+// - Counting data and included together, exceeding the cap errors with CodeMax at the root.
+func TestSingleRuleSet_WithMaxResources(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithMaxResources(2)
+	ctx := context.Background()
+
+	if _, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "id": "1", "attributes": {}}, "included": [{"type": "people", "id": "9"}]}`); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	_, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "id": "1", "attributes": {}}, "included": [{"type": "people", "id": "9"}, {"type": "people", "id": "10"}]}`)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Code != string(errors.CodeMax) {
+		t.Errorf("Expected code %s, got %s", errors.CodeMax, list[0].Code)
+	}
+	if list[0].Source != nil && list[0].Source.Pointer != "" {
+		t.Errorf("Expected the error at the document root, got %+v", list[0].Source)
+	}
+}
+
+// Requirements:
+// - Top-level @-members are captured in the envelope's AtMembers by Apply.
+// - Marshaling the envelope emits them again.
+func TestSingleRuleSet_TopLevelAtMembersRoundTrip(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	input := `{"@context": "https://schema.org", "data": {"type": "articles", "id": "1", "attributes": {"title": "Hi"}}}`
+
+	envelope, errs := ruleSet.Apply(context.Background(), input)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if envelope.AtMembers["@context"] != "https://schema.org" {
+		t.Errorf("Expected @context in AtMembers, got: %v", envelope.AtMembers)
+	}
+
+	output, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Expected marshal error to be nil, got: %s", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("Expected unmarshal error to be nil, got: %s", err)
+	}
+	if decoded["@context"] != "https://schema.org" {
+		t.Errorf("Expected @context in output, got: %s", output)
+	}
+}
+
+// Requirements:
+// - Extension members go to ExtensionMembers and @-members go to AtMembers, on the document and the resource.
+// - Neither bucket receives the other's members.
+func TestSingleRuleSet_MemberBuckets(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	input := `{"@context": "https://schema.org", "version:id": "7", "data": {"type": "articles", "id": "1", "attributes": {}, "@type": "Article", "version:id": "42"}}`
+
+	out, errs := ruleSet.Apply(context.Background(), input)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+
+	buckets := []struct {
+		name     string
+		ext, at  map[string]any
+		extKey   string
+		atKey    string
+		extValue any
+		atValue  any
+	}{
+		{"document", out.ExtensionMembers, out.AtMembers, "version:id", "@context", "7", "https://schema.org"},
+		{"resource", out.Data.ExtensionMembers, out.Data.AtMembers, "version:id", "@type", "42", "Article"},
+	}
+	for _, b := range buckets {
+		if b.ext[b.extKey] != b.extValue || len(b.ext) != 1 {
+			t.Errorf("%s: expected ExtensionMembers {%s: %v}, got: %v", b.name, b.extKey, b.extValue, b.ext)
+		}
+		if b.at[b.atKey] != b.atValue || len(b.at) != 1 {
+			t.Errorf("%s: expected AtMembers {%s: %v}, got: %v", b.name, b.atKey, b.atValue, b.at)
+		}
+	}
+}
+
+// Requirements:
+// - attributes, relationships, and meta set to null, an array, or a string error with CodeType at their pointer.
+func TestSingleRuleSet_NullObjectMembers(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithUnknownRelationships().
+		WithUnknownMeta()
+	ctx := context.Background()
+
+	for _, member := range []string{"attributes", "relationships", "meta"} {
+		for _, value := range []string{"null", "[]", `"x"`} {
+			input := `{"data": {"type": "articles", "id": "1", "` + member + `": ` + value + `}}`
+			_, errs := ruleSet.Apply(ctx, input)
+			if errs == nil {
+				t.Errorf("Expected an error for %s: %s", member, value)
+				continue
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Errorf("%s: %s: expected 1 error, got %d", member, value, len(list))
+				continue
+			}
+			if list[0].Code != string(errors.CodeType) {
+				t.Errorf("%s: %s: expected code %s, got %s", member, value, errors.CodeType, list[0].Code)
+			}
+			if want := "/data/" + member; list[0].Source == nil || list[0].Source.Pointer != want {
+				t.Errorf("%s: %s: expected pointer %s, got %+v", member, value, want, list[0].Source)
+			}
+		}
+	}
+}
+
+// Requirements:
+// - Under OPTIONS, an empty or invalid body is not validated and returns no error.
+func TestSingleRuleSet_OptionsSkipsValidation(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]()).WithRequired()
+	ctx := jsonapi.WithMethod(context.Background(), "OPTIONS")
+
+	for _, input := range []string{"", `{"data": {"type": "people"}}`} {
+		if _, errs := ruleSet.Apply(ctx, input); errs != nil {
+			t.Errorf("Expected errors to be nil for %q, got: %s", input, errs)
+		}
+	}
+}
+
+// Requirements:
+// - A POST body may identify the new resource with lid instead of id.
+// - The lid is decoded onto the datum and marshaled back without an id.
+// - An empty or reserved-character lid errors at /data/lid on POST.
+func TestSingleRuleSet_PostWithLid(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	ctx := jsonapi.WithMethod(context.Background(), "POST")
+
+	out, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "lid": "new-1", "attributes": {"title": "Hello"}}}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if out.Data.Lid != "new-1" {
+		t.Errorf(`Expected lid to be "new-1", got: %q`, out.Data.Lid)
+	}
+	if out.Data.ID != "" {
+		t.Errorf("Expected id to be empty, got: %q", out.Data.ID)
+	}
+
+	encoded, err := json.Marshal(out.Data)
+	if err != nil {
+		t.Fatalf("Expected marshal to succeed, got: %s", err)
+	}
+	if expected := `{"type": "articles", "lid": "new-1", "attributes": {"title": "Hello"}}`; !jsonEqual(string(encoded), expected) {
+		t.Errorf("Expected %s, got: %s", expected, encoded)
+	}
+
+	var decoded jsonapi.Datum[map[string]any]
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Expected unmarshal to succeed, got: %s", err)
+	}
+	if decoded.Lid != "new-1" || decoded.ID != "" {
+		t.Errorf(`Expected lid "new-1" and no id, got lid %q and id %q`, decoded.Lid, decoded.ID)
+	}
+
+	for _, lid := range []string{`""`, `"new/1"`} {
+		_, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "lid": `+lid+`, "attributes": {}}}`)
+		if errs == nil {
+			t.Fatalf("Expected lid %s to be rejected", lid)
+		}
+		list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+		if len(list) != 1 {
+			t.Fatalf("Expected 1 error, got %d: %+v", len(list), list)
+		}
+		if list[0].Source == nil || list[0].Source.Pointer != "/data/lid" {
+			t.Errorf("Expected pointer /data/lid, got %+v", list[0].Source)
+		}
+	}
+}
+
+// Requirements:
+// - The canonical type and each alias validate.
+// - The decoded type is always the canonical one.
+// - Any other type errors at /data/type.
+func TestSingleRuleSet_WithTypeAliases(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithTypeAliases("article", "posts")
+	ctx := context.Background()
+
+	for _, typeName := range []string{"articles", "article", "posts"} {
+		out, errs := ruleSet.Apply(ctx, `{"data": {"type": "`+typeName+`", "id": "1", "attributes": {}}}`)
+		if errs != nil {
+			t.Fatalf("Expected type %q to be accepted, got: %s", typeName, errs)
+		}
+		if out.Data.Type != "articles" {
+			t.Errorf(`Expected type %q to be normalized to "articles", got: %q`, typeName, out.Data.Type)
+		}
+	}
+
+	_, errs := ruleSet.Apply(ctx, `{"data": {"type": "people", "id": "1", "attributes": {}}}`)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != "/data/type" {
+		t.Errorf("Expected a single error at /data/type, got: %+v", list)
+	}
+}
+
+// Requirements:
+// - Relationship linkage errors carry the full pointer through relationships/<name>/data.
+// - A resource identifier without a type errors with CodeRequired at its type member.
+func TestSingleRuleSet_RelationshipLinkagePointer(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithRelationship("author", jsonapi.RelationshipRuleSet).
+		WithRelationship("tags", jsonapi.RelationshipRuleSet)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		body    string
+		pointer string
+	}{
+		{
+			"to-one",
+			`{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"author": {"data": {"id": "9"}}}}}`,
+			"/data/relationships/author/data/type",
+		},
+		{
+			"to-many",
+			`{"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"tags": {"data": [{"type": "tags", "id": "1"}, {"id": "2"}]}}}}`,
+			"/data/relationships/tags/data/1/type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := ruleSet.Apply(ctx, tt.body)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d: %+v", len(list), list)
+			}
+			if list[0].Code != string(errors.CodeRequired) {
+				t.Errorf("Expected code %s, got %s", errors.CodeRequired, list[0].Code)
+			}
+			if list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+				t.Errorf("Expected pointer %s, got %+v", tt.pointer, list[0].Source)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - data and errors together error at /errors, the conflicting member.
+// - A document without data, errors, or meta errors without a source, since it concerns the whole document.
+func TestSingleRuleSet_TopLevelMemberSource(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	ctx := context.Background()
+
+	_, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "id": "1", "attributes": {}}, "errors": []}`)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != "/errors" {
+		t.Errorf("Expected a single error at /errors, got: %+v", list)
+	}
+
+	_, errs = ruleSet.Apply(ctx, `{"links": {}}`)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list = jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 || list[0].Source != nil {
+		t.Errorf("Expected a single error without a source, got: %+v", list)
+	}
+}
+
+// Requirements:
+// - An unknown top-level member is ignored by default.
+// - With WithStrictTopLevel it errors with CodeUnexpected at its pointer.
+// - @-members and extension members are still accepted in strict mode.
+func TestSingleRuleSet_WithStrictTopLevel(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	ctx := context.Background()
+	body := `{"data": {"type": "articles", "id": "1", "attributes": {}}, "foo": 1}`
+
+	if _, errs := ruleSet.Apply(ctx, body); errs != nil {
+		t.Errorf("Expected unknown member to be ignored by default, got: %s", errs)
+	}
+
+	strict := ruleSet.WithStrictTopLevel()
+	_, errs := strict.Apply(ctx, body)
+	if errs == nil {
+		t.Fatal("Expected unknown member to be rejected in strict mode")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %+v", len(list), list)
+	}
+	if list[0].Code != string(errors.CodeUnexpected) {
+		t.Errorf("Expected code %s, got %s", errors.CodeUnexpected, list[0].Code)
+	}
+	if list[0].Source == nil || list[0].Source.Pointer != "/foo" {
+		t.Errorf("Expected pointer /foo, got %+v", list[0].Source)
+	}
+
+	if _, errs := strict.Apply(ctx, `{"data": {"type": "articles", "id": "1", "attributes": {}}, "@context": "x", "version:id": "1"}`); errs != nil {
+		t.Errorf("Expected @-members and extension members to be accepted, got: %s", errs)
+	}
+}
+
+// Requirements:
+// - Top-level links with valid values pass.
+// - A link that is not a string, object, or null errors at /links/<name>.
+// - A pagination link without an href errors at /links/<name>.
+func TestSingleRuleSet_DocumentLinks(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	collection := jsonapi.NewCollectionRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	ctx := context.Background()
+
+	if _, errs := ruleSet.Apply(ctx, `{"data": {"type": "articles", "id": "1", "attributes": {}}, "links": {"self": "/articles/1", "related": {"href": "/people/9"}}}`); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	tests := []struct {
+		name    string
+		apply   func(string) errors.ValidationError
+		body    string
+		pointer string
+	}{
+		{
+			"number",
+			func(body string) errors.ValidationError { _, errs := ruleSet.Apply(ctx, body); return errs },
+			`{"data": {"type": "articles", "id": "1", "attributes": {}}, "links": {"self": 1}}`,
+			"/links/self",
+		},
+		{
+			"collection number",
+			func(body string) errors.ValidationError { _, errs := collection.Apply(ctx, body); return errs },
+			`{"data": [], "links": {"next": 2}}`,
+			"/links/next",
+		},
+		{
+			"empty href",
+			func(body string) errors.ValidationError { _, errs := collection.Apply(ctx, body); return errs },
+			`{"data": [], "links": {"first": {"href": ""}}}`,
+			"/links/first",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.apply(tt.body)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+				t.Errorf("Expected a single error at %s, got: %+v", tt.pointer, list)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - Unusual primary data does not panic.
+// - null data is accepted, array data errors with CodeType at /data, and an empty document errors with CodeRequired.
+func TestSingleRuleSet_PrimaryDataShapes(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	ctx := context.Background()
+
+	if _, errs := ruleSet.Apply(ctx, `{"data": null}`); errs != nil {
+		t.Errorf("Expected null data to be accepted, got: %s", errs)
+	}
+
+	tests := []struct {
+		input   string
+		code    errors.ErrorCode
+		pointer string
+	}{
+		{`{"data": []}`, errors.CodeType, "/data"},
+		{`{"data": "articles"}`, errors.CodeType, "/data"},
+		{`{}`, errors.CodeRequired, ""},
+	}
+	for _, tt := range tests {
+		_, errs := ruleSet.Apply(ctx, tt.input)
+		if errs == nil {
+			t.Errorf("Expected an error for %s", tt.input)
+			continue
+		}
+		list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+		if len(list) != 1 {
+			t.Errorf("%s: expected 1 error, got %d: %+v", tt.input, len(list), list)
+			continue
+		}
+		if list[0].Code != string(tt.code) {
+			t.Errorf("%s: expected code %s, got %s", tt.input, tt.code, list[0].Code)
+		}
+		pointer := ""
+		if list[0].Source != nil {
+			pointer = list[0].Source.Pointer
+		}
+		if pointer != tt.pointer {
+			t.Errorf("%s: expected pointer %q, got %q", tt.input, tt.pointer, pointer)
+		}
+	}
+}
+
+// Requirements:
+// - A meta-only document passes with WithUnknownDocumentMeta and keeps its meta.
+// - The datum has no Fields, since there is no primary data.
+func TestSingleRuleSet_MetaOnlyDocument(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithUnknownDocumentMeta()
+
+	out, errs := ruleSet.Apply(context.Background(), `{"meta": {"count": 3}}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if out.Meta["count"] != float64(3) {
+		t.Errorf("Expected meta count 3, got: %v", out.Meta)
+	}
+	if out.Data.Fields != nil {
+		t.Errorf("Expected no fields, got: %v", out.Data.Fields.Values())
+	}
+}
@@ -0,0 +1,37 @@
+package jsonapi
+
+import (
+	"context"
+
+	"proto.zip/studio/validate/pkg/errors"
+)
+
+// canceledError is the validation error returned when the request context is done before validation
+// finishes. It reports the context error to errors.Is, e.g. errors.Is(err, context.Canceled).
+type canceledError struct {
+	*jsonAPIErrorWrapper
+	cause error
+}
+
+// Is reports whether target is the context error that stopped validation.
+func (e *canceledError) Is(target error) bool {
+	return target == e.cause
+}
+
+// evaluateCanceled returns a CodeCanceled error once ctx is canceled or past its deadline.
+// Rule sets call it at element boundaries so validation of a large document stops early.
+func evaluateCanceled(ctx context.Context) errors.ValidationError {
+	cause := ctx.Err()
+	if cause == nil {
+		return nil
+	}
+	return errors.Join(&canceledError{
+		jsonAPIErrorWrapper: &jsonAPIErrorWrapper{err: &Error{
+			Status: "503",
+			Code:   string(CodeCanceled),
+			Title:  "Validation canceled",
+			Detail: "Validation stopped: " + cause.Error(),
+		}},
+		cause: cause,
+	})
+}
@@ -0,0 +1,276 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// CollectionRuleSet validates a document whose primary data is an array of resource objects,
+// such as a bulk create request, and decodes it into a DatumCollectionEnvelope.
+type CollectionRuleSet[T any] struct {
+	documentOptions
+	datumRuleSet *DatumRuleSet[T]
+	metaRuleSet  *rules.ObjectRuleSet[map[string]any, string, any]
+	required     bool
+	rules.NoConflict[DatumCollectionEnvelope[T]]
+}
+
+// NewCollectionRuleSet returns a rule set for a resource collection document with the given type and attributes validation.
+func NewCollectionRuleSet[T any](typeName string, attributesRuleSet rules.RuleSet[T]) *CollectionRuleSet[T] {
+	return &CollectionRuleSet[T]{
+		datumRuleSet: NewDatumRuleSet(typeName, attributesRuleSet),
+		metaRuleSet:  rules.StringMap[any](),
+	}
+}
+
+// clone returns a shallow copy of the rule set for use in builder methods.
+func (ruleSet *CollectionRuleSet[T]) clone() *CollectionRuleSet[T] {
+	return &CollectionRuleSet[T]{
+		documentOptions: ruleSet.documentOptions,
+		datumRuleSet:    ruleSet.datumRuleSet,
+		metaRuleSet:     ruleSet.metaRuleSet,
+		required:        ruleSet.required,
+	}
+}
+
+// WithIDRule replaces the rule set used to validate resource ids (see DatumRuleSet.WithIDRule).
+func (ruleSet *CollectionRuleSet[T]) WithIDRule(idRuleSet rules.RuleSet[string]) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithIDRule(idRuleSet)
+	return newRuleSet
+}
+
+// WithTypeAliases also accepts each of typeNames as the resource type (see DatumRuleSet.WithTypeAliases).
+func (ruleSet *CollectionRuleSet[T]) WithTypeAliases(typeNames ...string) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithTypeAliases(typeNames...)
+	return newRuleSet
+}
+
+// WithRelationship registers a relationship name and its rule set for every resource in the collection.
+func (ruleSet *CollectionRuleSet[T]) WithRelationship(relName string, relRuleSet rules.RuleSet[Relationship]) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithRelationship(relName, relRuleSet)
+	return newRuleSet
+}
+
+// WithUnknownRelationships allows any relationship name with dynamic validation.
+func (ruleSet *CollectionRuleSet[T]) WithUnknownRelationships() *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithUnknownRelationships()
+	return newRuleSet
+}
+
+// WithRelationshipMeta validates the meta of the named relationship of every resource with metaRuleSet
+// (see DatumRuleSet.WithRelationshipMeta).
+func (ruleSet *CollectionRuleSet[T]) WithRelationshipMeta(relName string, metaRuleSet rules.RuleSet[map[string]any]) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithRelationshipMeta(relName, metaRuleSet)
+	return newRuleSet
+}
+
+// WithClientGeneratedID sets whether clients may send ids when creating resources (default false).
+func (ruleSet *CollectionRuleSet[T]) WithClientGeneratedID(allowed bool) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithClientGeneratedID(allowed)
+	return newRuleSet
+}
+
+// WithUnknownDocumentMeta allows any top-level document meta key.
+func (ruleSet *CollectionRuleSet[T]) WithUnknownDocumentMeta() *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.metaRuleSet = newRuleSet.metaRuleSet.WithUnknown()
+	return newRuleSet
+}
+
+// WithStrictMeta requires every key in the document, resource, and relationship meta, including nested keys,
+// to be a valid member name.
+func (ruleSet *CollectionRuleSet[T]) WithStrictMeta() *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.metaRuleSet = newRuleSet.metaRuleSet.WithRule(MetaMemberNamesRule)
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithStrictMeta()
+	return newRuleSet
+}
+
+// WithSupportedVersions sets the JSON:API versions accepted in the jsonapi member (default 1.0 and 1.1).
+func (ruleSet *CollectionRuleSet[T]) WithSupportedVersions(versions ...Version) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.versions = append([]Version{}, versions...)
+	return newRuleSet
+}
+
+// WithRequiredExtensions requires the jsonapi member to list each extension URI in its ext array
+// (see SingleRuleSet.WithRequiredExtensions).
+func (ruleSet *CollectionRuleSet[T]) WithRequiredExtensions(uris ...string) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.extensions = append(append([]string{}, ruleSet.extensions...), uris...)
+	return newRuleSet
+}
+
+// WithNegotiatedExtensionMembers only accepts extension members on the document and every resource whose
+// namespace was negotiated for the request (see SingleRuleSet.WithNegotiatedExtensionMembers).
+func (ruleSet *CollectionRuleSet[T]) WithNegotiatedExtensionMembers(exts ...Extension) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.negotiated = append([]Extension{}, exts...)
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithNegotiatedExtensionMembers(exts...)
+	return newRuleSet
+}
+
+// WithExtensions declares the extensions the document may use (see SingleRuleSet.WithExtensions).
+func (ruleSet *CollectionRuleSet[T]) WithExtensions(exts ...Extension) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.declared = append([]Extension{}, exts...)
+	newRuleSet.datumRuleSet = newRuleSet.datumRuleSet.WithExtensions(exts...)
+	return newRuleSet
+}
+
+// WithStrictMemberNames requires every object key in the document to be a valid member name
+// (see SingleRuleSet.WithStrictMemberNames).
+func (ruleSet *CollectionRuleSet[T]) WithStrictMemberNames() *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.strictNames = true
+	return newRuleSet
+}
+
+// WithMaxResources rejects documents with more than max resource objects across data and included,
+// before the resources are validated.
+func (ruleSet *CollectionRuleSet[T]) WithMaxResources(max int) *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.maxResources = max
+	return newRuleSet
+}
+
+// WithFullLinkage requires every included resource to be reachable from primary data (see SingleRuleSet.WithFullLinkage).
+func (ruleSet *CollectionRuleSet[T]) WithFullLinkage() *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.linkage = linkageFull
+	return newRuleSet
+}
+
+// WithDeepLinkage also requires included resources' relationships to resolve (see SingleRuleSet.WithDeepLinkage).
+func (ruleSet *CollectionRuleSet[T]) WithDeepLinkage() *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.linkage = linkageDeep
+	return newRuleSet
+}
+
+// WithStrictTopLevel rejects top-level members not defined by the spec (see SingleRuleSet.WithStrictTopLevel).
+func (ruleSet *CollectionRuleSet[T]) WithStrictTopLevel() *CollectionRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.strictTop = true
+	return newRuleSet
+}
+
+// WithRequired marks the document as required.
+func (ruleSet *CollectionRuleSet[T]) WithRequired() *CollectionRuleSet[T] {
+	if ruleSet.required {
+		return ruleSet
+	}
+
+	newRuleSet := ruleSet.clone()
+	newRuleSet.required = true
+	return newRuleSet
+}
+
+// Required reports whether the document is required.
+func (ruleSet *CollectionRuleSet[T]) Required() bool {
+	return ruleSet.required
+}
+
+// Apply decodes and validates the input (string or map) into the output envelope.
+func (ruleSet *CollectionRuleSet[T]) Apply(ctx context.Context, input any) (DatumCollectionEnvelope[T], errors.ValidationError) {
+	var zero DatumCollectionEnvelope[T]
+	if isOptions(ctx) {
+		return zero, nil
+	}
+
+	var decodedInput any
+	if inputStr, ok := input.(string); ok {
+		if err := json.Unmarshal([]byte(inputStr), &decodedInput); err != nil {
+			return zero, errors.Join(&jsonAPIErrorWrapper{err: MalformedJSONError([]byte(inputStr))})
+		}
+		input = decodedInput
+	} else if inputMap, ok := input.(map[string]any); ok {
+		decodedInput = inputMap
+	}
+
+	if errs := evaluateCanceled(ctx); errs != nil {
+		return zero, errs
+	}
+	if errs := evaluateTopLevelMembers(ctx, decodedInput); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+	if errs := evaluateNullCollectionItems(ctx, decodedInput); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+	if errs := ruleSet.evaluateDocument(ctx, decodedInput); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+
+	bodyValidator := rules.Struct[DatumCollectionEnvelope[T]]()
+	bodyValidator = bodyValidator.WithKey("data", rules.Slice[Datum[T]]().WithItemRuleSet(ruleSet.datumRuleSet).Any())
+	bodyValidator = bodyValidator.WithKey("meta", ruleSet.metaRuleSet.Any())
+	bodyValidator = bodyValidator.WithKey("links", DocumentLinksRuleSet.Any())
+	bodyValidator = bodyValidator.WithKey("included", IncludedRuleSet.Any())
+	bodyValidator = bodyValidator.WithKey("jsonapi", ruleSet.jsonAPIObjectRuleSet().Any())
+
+	bodyValidator = bodyValidator.WithDynamicBucket(atMembersKeyRule, "AtMembers")
+	bodyValidator = bodyValidator.WithDynamicBucket(extKeyRule, "ExtensionMembers")
+
+	envelope, err := bodyValidator.Apply(ctx, input)
+	if errs := evaluateCanceled(ctx); errs != nil {
+		// Report only the cancellation, not the errors of the items skipped because of it.
+		return zero, errs
+	}
+	if err != nil {
+		return zero, ToJSONAPIErrors(err, SourcePointer)
+	}
+	if errs := ruleSet.evaluateEnvelope(ctx, decodedInput, envelope.ExtensionMembers, envelope.JSONAPI); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+	return envelope, nil
+}
+
+// evaluateNullCollectionItems returns a CodeType error at /data/N for every null element of the data array.
+func evaluateNullCollectionItems(ctx context.Context, decodedInput any) errors.ValidationError {
+	inputMap, ok := decodedInput.(map[string]any)
+	if !ok {
+		return nil
+	}
+	data, ok := inputMap["data"].([]any)
+	if !ok {
+		return nil
+	}
+
+	var allErrors []error
+	dataCtx := rulecontext.WithPathString(ctx, "data")
+	for i, item := range data {
+		if item != nil {
+			continue
+		}
+		itemCtx := rulecontext.WithPathString(dataCtx, strconv.Itoa(i))
+		allErrors = append(allErrors, errors.Errorf(errors.CodeType, itemCtx, "resource object expected", "Collection data must contain resource objects, not null"))
+	}
+	return errors.Join(allErrors...)
+}
+
+// Evaluate validates a DatumCollectionEnvelope value and returns any validation errors.
+func (ruleSet *CollectionRuleSet[T]) Evaluate(ctx context.Context, value DatumCollectionEnvelope[T]) errors.ValidationError {
+	_, err := ruleSet.Apply(ctx, value)
+	return err
+}
+
+// Any returns the rule set as rules.RuleSet[any] for use with generic validators.
+func (ruleSet *CollectionRuleSet[T]) Any() rules.RuleSet[any] {
+	return rules.WrapAny[DatumCollectionEnvelope[T]](ruleSet)
+}
+
+// String returns a stable name for the rule set for error messages and debugging.
+func (ruleSet *CollectionRuleSet[T]) String() string {
+	return "CollectionRuleSet"
+}
@@ -0,0 +1,242 @@
+package jsonapi_test
+
+import (
+	"context"
+	stderrors "errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// Requirements:
+// - A data array of resource objects is decoded in order.
+func TestCollectionRuleSet_Valid(t *testing.T) {
+	ruleSet := jsonapi.NewCollectionRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+
+	out, errs := ruleSet.Apply(context.Background(), `{"data": [{"type": "articles", "id": "1", "attributes": {"title": "A"}}, {"type": "articles", "id": "2", "attributes": {"title": "B"}}]}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if len(out.Data) != 2 || out.Data[0].ID != "1" || out.Data[1].ID != "2" {
+		t.Errorf("Expected two decoded resources, got: %+v", out.Data)
+	}
+}
+
+// Requirements:
+// - A null element of the data array errors with CodeType at /data/N.
+func TestCollectionRuleSet_NullElements(t *testing.T) {
+	ruleSet := jsonapi.NewCollectionRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+
+	tests := []struct {
+		input   string
+		pointer string
+	}{
+		{`{"data": [null]}`, "/data/0"},
+		{`{"data": [{"type": "articles", "id": "1", "attributes": {}}, null]}`, "/data/1"},
+	}
+	for _, tt := range tests {
+		_, errs := ruleSet.Apply(context.Background(), tt.input)
+		if errs == nil {
+			t.Errorf("Expected an error for %s", tt.input)
+			continue
+		}
+		list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+		if len(list) != 1 {
+			t.Errorf("%s: expected 1 error, got %d", tt.input, len(list))
+			continue
+		}
+		if list[0].Code != string(errors.CodeType) {
+			t.Errorf("%s: expected code %s, got %s", tt.input, errors.CodeType, list[0].Code)
+		}
+		if list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+			t.Errorf("%s: expected pointer %s, got %+v", tt.input, tt.pointer, list[0].Source)
+		}
+	}
+}
+
+// Requirements:
+// - A canceled context stops validation of a large data array early.
+// - A single CodeCanceled error is returned and errors.Is reports context.Canceled.
+// - A context canceled before Apply validates no resources.
+func TestCollectionRuleSet_Canceled(t *testing.T) {
+	const n = 10000
+	data := make([]any, n)
+	for i := range data {
+		data[i] = map[string]any{"type": "articles", "id": strconv.Itoa(i), "attributes": map[string]any{}}
+	}
+	input := map[string]any{"data": data}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var validated atomic.Int64
+	attributes := rules.StringMap[any]().WithUnknown().WithRuleFunc(func(ctx context.Context, m map[string]any) errors.ValidationError {
+		validated.Add(1)
+		cancel()
+		return nil
+	})
+	ruleSet := jsonapi.NewCollectionRuleSet[map[string]any]("articles", attributes)
+
+	_, errs := ruleSet.Apply(ctx, input)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	if !stderrors.Is(errs, context.Canceled) {
+		t.Errorf("Expected errors.Is(errs, context.Canceled), got: %s", errs)
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 || list[0].Code != string(jsonapi.CodeCanceled) {
+		t.Fatalf("Expected a single %s error, got: %+v", jsonapi.CodeCanceled, list)
+	}
+	if count := validated.Load(); count >= n {
+		t.Errorf("Expected validation to stop early, validated %d of %d resources", count, n)
+	}
+
+	validated.Store(0)
+	if _, errs := ruleSet.Apply(ctx, input); !stderrors.Is(errs, context.Canceled) {
+		t.Errorf("Expected errors.Is(errs, context.Canceled), got: %s", errs)
+	}
+	if count := validated.Load(); count != 0 {
+		t.Errorf("Expected no resources to be validated, validated %d", count)
+	}
+}
+
+// Requirements:
+// - ApplyReader passes each resource of a streamed data array to the handler in order.
+// - An invalid element errors with its /data/N pointer and later elements are not handled.
+// - An error returned by the handler is returned as is.
+func TestCollectionRuleSet_ApplyReader(t *testing.T) {
+	ruleSet := jsonapi.NewCollectionRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	ctx := context.Background()
+
+	var ids []string
+	handler := func(datum jsonapi.Datum[map[string]any]) error {
+		ids = append(ids, datum.ID)
+		return nil
+	}
+
+	body := `{"data": [{"type": "articles", "id": "1", "attributes": {}}, {"type": "articles", "id": "2", "attributes": {}}, {"type": "articles", "id": "3", "attributes": {}}], "meta": {}}`
+	if err := ruleSet.ApplyReader(ctx, strings.NewReader(body), handler); err != nil {
+		t.Fatalf("Expected error to be nil, got: %s", err)
+	}
+	if strings.Join(ids, ",") != "1,2,3" {
+		t.Errorf("Expected handled ids 1,2,3, got: %v", ids)
+	}
+
+	ids = nil
+	body = `{"data": [{"type": "articles", "id": "1", "attributes": {}}, {"type": "people", "id": "2", "attributes": {}}, {"type": "articles", "id": "3", "attributes": {}}]}`
+	err := ruleSet.ApplyReader(ctx, strings.NewReader(body), handler)
+	verr, ok := err.(errors.ValidationError)
+	if !ok || verr == nil {
+		t.Fatalf("Expected a validation error, got: %v", err)
+	}
+	list := jsonapi.ErrorsFromValidationError(verr, jsonapi.SourcePointer)
+	if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != "/data/1/type" {
+		t.Errorf("Expected a single error at /data/1/type, got: %+v", list)
+	}
+	if strings.Join(ids, ",") != "1" {
+		t.Errorf("Expected only id 1 to be handled, got: %v", ids)
+	}
+
+	stop := stderrors.New("stop")
+	body = `{"data": [{"type": "articles", "id": "1", "attributes": {}}, {"type": "articles", "id": "2", "attributes": {}}]}`
+	err = ruleSet.ApplyReader(ctx, strings.NewReader(body), func(datum jsonapi.Datum[map[string]any]) error { return stop })
+	if err != stop {
+		t.Errorf("Expected the handler error, got: %v", err)
+	}
+}
+
+// Requirements:
+// - ApplyReader returns an error without calling the handler when linkage checks are enabled.
+// - OPTIONS requests are not validated and the handler is not called.
+func TestCollectionRuleSet_ApplyReaderSkips(t *testing.T) {
+	ruleSet := jsonapi.NewCollectionRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	body := `{"data": [{"type": "articles", "id": "1", "attributes": {}}]}`
+
+	handled := 0
+	handler := func(datum jsonapi.Datum[map[string]any]) error {
+		handled++
+		return nil
+	}
+
+	err := ruleSet.WithFullLinkage().ApplyReader(context.Background(), strings.NewReader(body), handler)
+	if err == nil {
+		t.Error("Expected an error for WithFullLinkage, got: nil")
+	}
+	if _, ok := err.(errors.ValidationError); ok {
+		t.Errorf("Expected a non-validation error, got: %v", err)
+	}
+
+	ctx := jsonapi.WithMethod(context.Background(), "OPTIONS")
+	if err := ruleSet.ApplyReader(ctx, strings.NewReader(`not json`), handler); err != nil {
+		t.Errorf("Expected error to be nil for OPTIONS, got: %s", err)
+	}
+	if handled != 0 {
+		t.Errorf("Expected the handler not to be called, got %d calls", handled)
+	}
+}
+
+// Requirements:
+// - The document options of SingleRuleSet apply to collections through both Apply and ApplyReader.
+// - Each option reports its error at the same pointer as for a single resource document.
+func TestCollectionRuleSet_DocumentOptions(t *testing.T) {
+	base := jsonapi.NewCollectionRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	item := `{"type": "articles", "id": "1", "attributes": {}}`
+	requireCount := rules.StringMap[any]().WithKey("count", rules.Int().WithRequired().Any())
+
+	tests := []struct {
+		name    string
+		ruleSet *jsonapi.CollectionRuleSet[map[string]any]
+		body    string
+		code    errors.ErrorCode
+		pointer string
+	}{
+		{"supported versions", base.WithSupportedVersions(jsonapi.Version_1_1), `{"jsonapi": {"version": "1.0"}, "data": [` + item + `]}`, "", "/jsonapi/version"},
+		{"required extensions", base.WithRequiredExtensions("https://example.com/ext/version"), `{"data": [` + item + `]}`, errors.CodeRequired, "/jsonapi"},
+		{"negotiated extension members", base.WithNegotiatedExtensionMembers(), `{"data": [` + item + `], "foo:bar": 1}`, errors.CodeUnexpected, "/foo:bar"},
+		{"id rule", base.WithIDRule(rules.String().WithMinLen(3)), `{"data": [` + item + `]}`, errors.CodeMin, "/data/0/id"},
+		{"strict meta", base.WithUnknownDocumentMeta().WithStrictMeta(), `{"meta": {"a.b": 1}, "data": [` + item + `]}`, errors.CodeUnexpected, "/meta/a.b"},
+		{"strict member names", base.WithStrictMemberNames(), `{"data": [{"type": "articles", "id": "1", "attributes": {"a.b": 1}}]}`, errors.CodeUnexpected, "/data/0/attributes/a.b"},
+		{"max resources", base.WithMaxResources(1), `{"data": [` + item + `, ` + item + `]}`, errors.CodeMax, ""},
+		{"declared extensions", base.WithExtensions(jsonapi.Extension{URI: "https://jsonapi.org/ext/atomic", Prefix: "atomic"}), `{"data": [` + item + `], "foo:bar": 1}`, errors.CodeUnexpected, "/foo:bar"},
+		{"type aliases", base.WithTypeAliases("posts"), `{"data": [{"type": "people", "id": "1", "attributes": {}}]}`, "", "/data/0/type"},
+		{"relationship meta", base.WithRelationship("comments", jsonapi.RelationshipRuleSet).WithRelationshipMeta("comments", requireCount), `{"data": [{"type": "articles", "id": "1", "attributes": {}, "relationships": {"comments": {"data": []}}}]}`, errors.CodeRequired, "/data/0/relationships/comments/meta/count"},
+	}
+	for _, tt := range tests {
+		check := func(t *testing.T, errs errors.ValidationError) {
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d: %+v", len(list), list)
+			}
+			if tt.code != "" && list[0].Code != string(tt.code) {
+				t.Errorf("Expected code %s, got %s", tt.code, list[0].Code)
+			}
+			pointer := ""
+			if list[0].Source != nil {
+				pointer = list[0].Source.Pointer
+			}
+			if pointer != tt.pointer {
+				t.Errorf("Expected pointer %q, got %q", tt.pointer, pointer)
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := tt.ruleSet.Apply(context.Background(), tt.body)
+			check(t, errs)
+		})
+		t.Run(tt.name+" streamed", func(t *testing.T) {
+			err := tt.ruleSet.ApplyReader(context.Background(), strings.NewReader(tt.body), func(jsonapi.Datum[map[string]any]) error { return nil })
+			verr, ok := err.(errors.ValidationError)
+			if !ok {
+				t.Fatalf("Expected a validation error, got: %v", err)
+			}
+			check(t, verr)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
+)
+
+// ApplyReader validates a collection document read from r, decoding the elements of the data array one
+// at a time so memory use is bounded by the largest element rather than the whole document. Each valid
+// resource is passed to handler as soon as it is decoded. Once an element is invalid the handler is no
+// longer called, but the remaining elements are still validated so every error is reported with its
+// /data/N pointer.
+//
+// Because handler runs before the rest of the document is read, ApplyReader can return a non-nil error
+// after handler has been called: a later element may be invalid, or a member after data (e.g. errors,
+// links, or jsonapi) may make the document invalid. Handlers with side effects must be able to undo them,
+// for example by writing inside a transaction that is rolled back when ApplyReader returns an error.
+//
+// Validation errors are returned as an errors.ValidationError. An error returned by handler stops
+// decoding and is returned as is. Linkage checks need the whole document, so a rule set with
+// WithFullLinkage or WithDeepLinkage returns an error without reading r. WithMaxResources stops decoding
+// as soon as the limit is exceeded. OPTIONS requests are not validated and handler is not called.
+func (ruleSet *CollectionRuleSet[T]) ApplyReader(ctx context.Context, r io.Reader, handler func(Datum[T]) error) error {
+	if isOptions(ctx) {
+		return nil
+	}
+	if ruleSet.linkage != linkageNone {
+		return fmt.Errorf("jsonapi: ApplyReader does not support WithFullLinkage or WithDeepLinkage")
+	}
+	if errs := evaluateCanceled(ctx); errs != nil {
+		return errs
+	}
+
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return streamMalformedJSONError(err)
+	}
+
+	// seen records the members present, for the document-level checks that only look at presence.
+	seen := make(map[string]any)
+	// countResources adds n to the resource objects read from data and included, for WithMaxResources.
+	resources := 0
+	countResources := func(n int) errors.ValidationError {
+		resources += n
+		return ruleSet.evaluateStreamedResourceCount(ctx, resources)
+	}
+	var extensionMembers map[string]any
+	var jsonAPI *JSONAPIObject
+	var allErrors []error
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return streamMalformedJSONError(err)
+		}
+		key := token.(string)
+		seen[key] = true
+		keyCtx := rulecontext.WithPathString(ctx, key)
+
+		if key == "data" {
+			dataErrors, err := ruleSet.streamData(keyCtx, dec, countResources, handler)
+			if err != nil {
+				return err
+			}
+			allErrors = append(allErrors, dataErrors...)
+			continue
+		}
+
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return streamMalformedJSONError(err)
+		}
+		if ruleSet.strictNames {
+			allErrors = append(allErrors, evaluateMemberNames(ctx, map[string]any{key: value})...)
+		}
+		var errs errors.ValidationError
+		switch {
+		case key == "meta":
+			_, errs = ruleSet.metaRuleSet.Apply(keyCtx, value)
+		case key == "links":
+			_, errs = DocumentLinksRuleSet.Apply(keyCtx, value)
+		case key == "included":
+			if included, ok := value.([]any); ok {
+				if errs := countResources(len(included)); errs != nil {
+					return errs
+				}
+			}
+			_, errs = IncludedRuleSet.Apply(keyCtx, value)
+		case key == "jsonapi":
+			jsonAPI, errs = ruleSet.jsonAPIObjectRuleSet().Apply(keyCtx, value)
+		case extKeyRule.Evaluate(ctx, key) == nil:
+			if extensionMembers == nil {
+				extensionMembers = make(map[string]any)
+			}
+			extensionMembers[key] = value
+		case atMembersKeyRule.Evaluate(ctx, key) == nil:
+			// @-members are accepted as is.
+		case !ruleSet.strictTop:
+			// Other members are ignored unless WithStrictTopLevel is set.
+		default:
+			errs = errors.Errorf(errors.CodeUnexpected, keyCtx, "unexpected member", "Unexpected top-level member %q", key)
+		}
+		if errs != nil {
+			allErrors = append(allErrors, errors.Unwrap(errs)...)
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return streamMalformedJSONError(err)
+	}
+
+	if errs := evaluateTopLevelMembers(ctx, seen); errs != nil {
+		allErrors = append(allErrors, errors.Unwrap(errs)...)
+	}
+	if errs := ruleSet.evaluateEnvelope(ctx, seen, extensionMembers, jsonAPI); errs != nil {
+		allErrors = append(allErrors, errors.Unwrap(errs)...)
+	}
+	if errs := errors.Join(allErrors...); errs != nil {
+		return ToJSONAPIErrors(errs, SourcePointer)
+	}
+	return nil
+}
+
+// streamData validates the elements of the data array in dec one at a time and passes each valid
+// resource to handler, passing each element to countResources. It returns the validation errors of the elements,
+// or a non-nil error that stops decoding: data that is not an array, malformed JSON, a canceled context,
+// too many resources, or an error returned by handler.
+func (ruleSet *CollectionRuleSet[T]) streamData(ctx context.Context, dec *json.Decoder, countResources func(int) errors.ValidationError, handler func(Datum[T]) error) ([]error, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, streamMalformedJSONError(err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		// The rest of the document is not read, since data may be an object of any size.
+		return nil, ToJSONAPIErrors(errors.Errorf(errors.CodeType, ctx, "array expected", "Collection data must be an array of resource objects"), SourcePointer)
+	}
+
+	var allErrors []error
+	for i := 0; dec.More(); i++ {
+		if errs := evaluateCanceled(ctx); errs != nil {
+			return nil, errs
+		}
+		if errs := countResources(1); errs != nil {
+			return nil, errs
+		}
+		itemCtx := rulecontext.WithPathString(ctx, strconv.Itoa(i))
+
+		var item any
+		if err := dec.Decode(&item); err != nil {
+			return nil, streamMalformedJSONError(err)
+		}
+		if ruleSet.strictNames {
+			allErrors = append(allErrors, evaluateMemberNames(itemCtx, item)...)
+		}
+		if item == nil {
+			allErrors = append(allErrors, errors.Errorf(errors.CodeType, itemCtx, "resource object expected", "Collection data must contain resource objects, not null"))
+			continue
+		}
+		datum, errs := ruleSet.datumRuleSet.Apply(itemCtx, item)
+		if errs != nil {
+			allErrors = append(allErrors, errors.Unwrap(errs)...)
+			continue
+		}
+		if len(allErrors) > 0 {
+			continue
+		}
+		if err := handler(datum); err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return nil, streamMalformedJSONError(err)
+	}
+	return allErrors, nil
+}
+
+// evaluateStreamedResourceCount returns the WithMaxResources error, converted for the response, once the
+// number of resources read exceeds the limit. Unlike Apply, the resources read so far have been validated.
+func (ruleSet *CollectionRuleSet[T]) evaluateStreamedResourceCount(ctx context.Context, resources int) errors.ValidationError {
+	if ruleSet.maxResources <= 0 || resources <= ruleSet.maxResources {
+		return nil
+	}
+	return ToJSONAPIErrors(errors.Errorf(errors.CodeMax, ctx, "too many resources", "Document contains more than %d resources", ruleSet.maxResources), SourcePointer)
+}
+
+// expectDelim reads the next token from dec and returns an error if it is not delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if got, ok := token.(json.Delim); !ok || got != delim {
+		return fmt.Errorf("expected %v at offset %d", delim, dec.InputOffset())
+	}
+	return nil
+}
+
+// streamMalformedJSONError returns the malformed JSON error for a streamed body. Unlike MalformedJSONError
+// it has no source pointer, since the body has not been kept to locate the error in.
+func streamMalformedJSONError(err error) errors.ValidationError {
+	return errors.Join(&jsonAPIErrorWrapper{err: &Error{
+		Status: "400",
+		Code:   string(CodeMalformedJSON),
+		Title:  "Invalid JSON encoding",
+		Detail: "Body must be Json encoded: " + err.Error(),
+	}})
+}
@@ -2,6 +2,9 @@ package jsonapi
 
 import (
 	"context"
+	"sync"
+
+	"proto.zip/studio/validate/pkg/errors"
 )
 
 type contextKey string
@@ -33,3 +36,54 @@ func IdFromContext(ctx context.Context) string {
 
 	return ""
 }
+
+// WithExtensions stores the extensions negotiated for the request (e.g. from the Content-Type ext parameter).
+func WithExtensions(ctx context.Context, exts ...Extension) context.Context {
+	return context.WithValue(ctx, contextKey("extensions"), exts)
+}
+
+// ExtensionsFromContext returns the negotiated extensions stored in the context, or nil if unset.
+func ExtensionsFromContext(ctx context.Context) []Extension {
+	if exts, ok := ctx.Value(contextKey("extensions")).([]Extension); ok {
+		return exts
+	}
+
+	return nil
+}
+
+// warningCollector accumulates non-fatal warnings recorded while validating a request.
+type warningCollector struct {
+	mu       sync.Mutex
+	warnings []error
+}
+
+// WithWarnings returns a context that collects non-fatal warnings recorded by validators
+// (e.g. an ignored client-generated id). Read them back with WarningsFromContext.
+func WithWarnings(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey("warnings"), &warningCollector{})
+}
+
+// WarningsFromContext returns the warnings recorded in the context, or nil if there are none
+// or the context was not created with WithWarnings.
+func WarningsFromContext(ctx context.Context) errors.ValidationError {
+	collector, ok := ctx.Value(contextKey("warnings")).(*warningCollector)
+	if !ok {
+		return nil
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	return errors.Join(collector.warnings...)
+}
+
+// addWarning records a warning in the context; it is dropped if the context does not collect warnings.
+func addWarning(ctx context.Context, warning errors.ValidationError) {
+	collector, ok := ctx.Value(contextKey("warnings")).(*warningCollector)
+	if !ok {
+		return
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.warnings = append(collector.warnings, errors.Unwrap(warning)...)
+}
@@ -67,3 +67,17 @@ func TestContext_MethodAndId(t *testing.T) {
 		t.Errorf("Expected id to be %q, got %q", id, retrievedId)
 	}
 }
+
+func TestWithExtensions(t *testing.T) {
+	ctx := context.Background()
+	if exts := jsonapi.ExtensionsFromContext(ctx); exts != nil {
+		t.Errorf("Expected extensions to be nil, got %v", exts)
+	}
+
+	ext := jsonapi.Extension{URI: "https://jsonapi.org/ext/version", Prefix: "version"}
+	ctx = jsonapi.WithExtensions(ctx, ext)
+	exts := jsonapi.ExtensionsFromContext(ctx)
+	if len(exts) != 1 || exts[0] != ext {
+		t.Errorf("Expected extensions to be %v, got %v", []jsonapi.Extension{ext}, exts)
+	}
+}
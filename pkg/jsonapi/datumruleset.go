@@ -2,20 +2,35 @@ package jsonapi
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
 
 	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
 	"proto.zip/studio/validate/pkg/rules"
 )
 
 type DatumRuleSet[T any] struct {
-	idRuleSet            rules.RuleSet[string]
-	typeRuleSet          *rules.ConstantRuleSet[string]
-	relationshipsRuleSet *rules.ObjectRuleSet[map[string]Relationship, string, Relationship]
-	attributesRuleSet    rules.RuleSet[T]
-	linksRuleSet         *rules.ObjectRuleSet[map[string]Link, string, Link]
-	metaRuleSet          *rules.ObjectRuleSet[map[string]any, string, any]
-	required             bool
-	errorConfig          *errors.ErrorConfig
+	idRuleSet             rules.RuleSet[string]
+	typeRuleSet           *rules.ConstantRuleSet[string]
+	typeResolver          func(ctx context.Context, typeName string) bool
+	typeAliases           []string
+	relationshipsRuleSet  *rules.ObjectRuleSet[map[string]Relationship, string, Relationship]
+	attributesRuleSet     rules.RuleSet[T]
+	linksRuleSet          *rules.ObjectRuleSet[map[string]Link, string, Link]
+	metaRuleSet           *rules.ObjectRuleSet[map[string]any, string, any]
+	metaHook              rules.RuleSet[map[string]any]
+	requiredRelationships []string
+	relationshipTypes     map[string]string
+	relationshipMeta      map[string]rules.RuleSet[map[string]any]
+	strictMeta            bool
+	clientGeneratedID     bool
+	ignoreClientID        bool
+	negotiatedExtensions  []Extension
+	declaredExtensions    []Extension
+	required              bool
+	errorConfig           *errors.ErrorConfig
 	rules.NoConflict[Datum[T]]
 }
 
@@ -35,17 +50,53 @@ func NewDatumRuleSet[T any](typeName string, attributesRuleSet rules.RuleSet[T])
 // clone returns a shallow copy of the rule set for use in builder methods.
 func (ruleSet *DatumRuleSet[T]) clone() *DatumRuleSet[T] {
 	return &DatumRuleSet[T]{
-		idRuleSet:            ruleSet.idRuleSet,
-		typeRuleSet:          ruleSet.typeRuleSet,
-		relationshipsRuleSet: ruleSet.relationshipsRuleSet,
-		attributesRuleSet:    ruleSet.attributesRuleSet,
-		linksRuleSet:         ruleSet.linksRuleSet,
-		required:             ruleSet.required,
-		metaRuleSet:          ruleSet.metaRuleSet,
-		errorConfig:          ruleSet.errorConfig,
+		idRuleSet:             ruleSet.idRuleSet,
+		typeRuleSet:           ruleSet.typeRuleSet,
+		typeResolver:          ruleSet.typeResolver,
+		typeAliases:           ruleSet.typeAliases,
+		relationshipsRuleSet:  ruleSet.relationshipsRuleSet,
+		attributesRuleSet:     ruleSet.attributesRuleSet,
+		linksRuleSet:          ruleSet.linksRuleSet,
+		requiredRelationships: ruleSet.requiredRelationships,
+		relationshipTypes:     ruleSet.relationshipTypes,
+		relationshipMeta:      ruleSet.relationshipMeta,
+		strictMeta:            ruleSet.strictMeta,
+		clientGeneratedID:     ruleSet.clientGeneratedID,
+		ignoreClientID:        ruleSet.ignoreClientID,
+		negotiatedExtensions:  ruleSet.negotiatedExtensions,
+		declaredExtensions:    ruleSet.declaredExtensions,
+		required:              ruleSet.required,
+		metaRuleSet:           ruleSet.metaRuleSet,
+		metaHook:              ruleSet.metaHook,
+		errorConfig:           ruleSet.errorConfig,
 	}
 }
 
+// WithIDRule replaces the rule set used to validate the resource id (default IDRuleSet),
+// e.g. to require UUIDs, numeric ids, or a maximum length.
+func (ruleSet *DatumRuleSet[T]) WithIDRule(idRuleSet rules.RuleSet[string]) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.idRuleSet = idRuleSet
+	return newRuleSet
+}
+
+// WithTypeResolver delegates type validation to resolver instead of requiring the type given to NewDatumRuleSet.
+// This is useful when the set of valid types is dynamic, such as a gateway backed by a type registry.
+// A type for which resolver returns false is rejected; the decoded datum keeps the type from the input.
+func (ruleSet *DatumRuleSet[T]) WithTypeResolver(resolver func(ctx context.Context, typeName string) bool) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.typeResolver = resolver
+	return newRuleSet
+}
+
+// WithTypeAliases also accepts each of typeNames as the resource type, e.g. a singular or legacy name.
+// The decoded datum always has the type given to NewDatumRuleSet.
+func (ruleSet *DatumRuleSet[T]) WithTypeAliases(typeNames ...string) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.typeAliases = append(append([]string{}, ruleSet.typeAliases...), typeNames...)
+	return newRuleSet
+}
+
 // WithRelationship registers a relationship name and its rule set.
 func (ruleSet *DatumRuleSet[T]) WithRelationship(relName string, relRuleSet rules.RuleSet[Relationship]) *DatumRuleSet[T] {
 	newRuleSet := ruleSet.clone()
@@ -53,6 +104,39 @@ func (ruleSet *DatumRuleSet[T]) WithRelationship(relName string, relRuleSet rule
 	return newRuleSet
 }
 
+// WithTypedRelationship registers a relationship name and its rule set, and requires every resource
+// identifier in its linkage to have the expected type.
+func (ruleSet *DatumRuleSet[T]) WithTypedRelationship(relName, expectedType string, relRuleSet rules.RuleSet[Relationship]) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.WithRelationship(relName, relRuleSet)
+	newRuleSet.relationshipTypes = make(map[string]string, len(ruleSet.relationshipTypes)+1)
+	for name, typeName := range ruleSet.relationshipTypes {
+		newRuleSet.relationshipTypes[name] = typeName
+	}
+	newRuleSet.relationshipTypes[relName] = expectedType
+	return newRuleSet
+}
+
+// WithRelationshipMeta validates the meta of the named relationship with metaRuleSet, for example to require
+// comments.meta.count. The rule set runs whenever the relationship is present, receiving an empty map when
+// it has no meta, and errors are reported at /relationships/<name>/meta/<key>.
+func (ruleSet *DatumRuleSet[T]) WithRelationshipMeta(relName string, metaRuleSet rules.RuleSet[map[string]any]) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.relationshipMeta = make(map[string]rules.RuleSet[map[string]any], len(ruleSet.relationshipMeta)+1)
+	for name, hook := range ruleSet.relationshipMeta {
+		newRuleSet.relationshipMeta[name] = hook
+	}
+	newRuleSet.relationshipMeta[relName] = metaRuleSet
+	return newRuleSet
+}
+
+// WithRequiredRelationship marks a relationship as required when creating a resource (POST).
+// The relationship must be present with non-null data; its rule set is registered separately with WithRelationship.
+func (ruleSet *DatumRuleSet[T]) WithRequiredRelationship(relName string) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.requiredRelationships = append(append([]string{}, ruleSet.requiredRelationships...), relName)
+	return newRuleSet
+}
+
 // WithUnknownRelationships allows any relationship name with dynamic validation.
 func (ruleSet *DatumRuleSet[T]) WithUnknownRelationships() *DatumRuleSet[T] {
 	newRuleSet := ruleSet.clone()
@@ -74,6 +158,58 @@ func (ruleSet *DatumRuleSet[T]) WithUnknownMeta() *DatumRuleSet[T] {
 	return newRuleSet
 }
 
+// WithMetaRuleSet validates the resource meta with metaRuleSet, for example to require specific keys.
+// The rule set also runs when meta is absent, receiving an empty map. Keys not covered by WithMeta are allowed.
+func (ruleSet *DatumRuleSet[T]) WithMetaRuleSet(metaRuleSet rules.RuleSet[map[string]any]) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.metaRuleSet = newRuleSet.metaRuleSet.WithUnknown()
+	newRuleSet.metaHook = metaRuleSet
+	return newRuleSet
+}
+
+// WithStrictMeta requires every key in the resource meta and in the meta of its relationships, including
+// nested keys, to be a valid member name.
+func (ruleSet *DatumRuleSet[T]) WithStrictMeta() *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.metaRuleSet = newRuleSet.metaRuleSet.WithRule(MetaMemberNamesRule)
+	newRuleSet.strictMeta = true
+	return newRuleSet
+}
+
+// WithClientGeneratedID sets whether clients may send an id when creating a resource (default false).
+// When false, a POST request with an id is rejected as forbidden. A lid is always allowed.
+func (ruleSet *DatumRuleSet[T]) WithClientGeneratedID(allowed bool) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.clientGeneratedID = allowed
+	return newRuleSet
+}
+
+// WithIgnoredClientID accepts an id on a POST request but discards it instead of rejecting the request.
+// A "client-generated id ignored" warning is recorded in the context (see WithWarnings).
+// This has no effect when client-generated ids are allowed with WithClientGeneratedID.
+func (ruleSet *DatumRuleSet[T]) WithIgnoredClientID() *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.ignoreClientID = true
+	return newRuleSet
+}
+
+// WithNegotiatedExtensionMembers only accepts extension members (namespace:member) whose namespace was
+// negotiated for the request (see WithExtensions). exts maps supported extension URIs to their namespaces.
+func (ruleSet *DatumRuleSet[T]) WithNegotiatedExtensionMembers(exts ...Extension) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.negotiatedExtensions = append([]Extension{}, exts...)
+	return newRuleSet
+}
+
+// WithExtensions declares the extensions the resource may use. Extension members (namespace:member) whose
+// namespace is not the prefix of a declared extension are rejected. With no declared extensions, any
+// extension member is accepted.
+func (ruleSet *DatumRuleSet[T]) WithExtensions(exts ...Extension) *DatumRuleSet[T] {
+	newRuleSet := ruleSet.clone()
+	newRuleSet.declaredExtensions = append([]Extension{}, exts...)
+	return newRuleSet
+}
+
 // WithRequired marks the resource object as required when used as primary data.
 func (ruleSet *DatumRuleSet[T]) WithRequired() *DatumRuleSet[T] {
 	if ruleSet.required {
@@ -165,10 +301,29 @@ func (ruleSet *DatumRuleSet[T]) Apply(ctx context.Context, input any) (Datum[T],
 		ctx = errors.WithErrorConfig(ctx, ruleSet.errorConfig)
 	}
 
+	if errs := evaluateCanceled(ctx); errs != nil {
+		return zero, errs
+	}
+	if errs := evaluateObjectMembers(ctx, input, "attributes", "relationships", "meta"); errs != nil {
+		return zero, errs
+	}
+	if errs := evaluateTypeName(ctx, input); errs != nil {
+		return zero, errs
+	}
+	if errs := evaluateLid(ctx, input); errs != nil {
+		return zero, errs
+	}
+
 	datumValidator := rules.Struct[Datum[T]]().WithJson()
 	datumValidator = datumValidator.WithKey("id", ruleSet.idRuleSet.Any())
 	datumValidator = datumValidator.WithKey("lid", rules.String().Any())
-	datumValidator = datumValidator.WithKey("type", ruleSet.typeRuleSet.Any())
+	if ruleSet.typeResolver != nil {
+		datumValidator = datumValidator.WithKey("type", rules.String().WithRule(typeNameRule{}).Any())
+	} else if len(ruleSet.typeAliases) > 0 {
+		datumValidator = datumValidator.WithKey("type", rules.String().WithRuleFunc(ruleSet.evaluateTypeAlias).Any())
+	} else {
+		datumValidator = datumValidator.WithKey("type", ruleSet.typeRuleSet.Any())
+	}
 	datumValidator = datumValidator.WithKey("attributes", ruleSet.attributesRuleSet.Any())
 	datumValidator = datumValidator.WithKey("relationships", ruleSet.relationshipsRuleSet.Any())
 	datumValidator = datumValidator.WithKey("links", ruleSet.linksRuleSet.Any())
@@ -181,14 +336,290 @@ func (ruleSet *DatumRuleSet[T]) Apply(ctx context.Context, input any) (Datum[T],
 	if errs != nil {
 		return zero, errs
 	}
-	out.Type = ruleSet.typeRuleSet.Value()
+	var allErrors []error
+	if out.Lid != "" && out.ID == "" && out.Type == "" {
+		// The type is implied when an id identifies the resource, but a lid alone does not identify it.
+		typeCtx := rulecontext.WithPathString(ctx, "type")
+		allErrors = append(allErrors, errors.Errorf(errors.CodeRequired, typeCtx, "type required", "Resource type is required when only lid is provided"))
+	}
+	if ruleSet.typeResolver != nil {
+		// Without a fixed type there is nothing to imply, so the type must always be present.
+		typeCtx := rulecontext.WithPathString(ctx, "type")
+		if out.Type == "" && (out.ID != "" || out.Lid == "") {
+			allErrors = append(allErrors, errors.Errorf(errors.CodeRequired, typeCtx, "type required", "Resource type is required"))
+		} else if out.Type != "" && !ruleSet.typeResolver(ctx, out.Type) {
+			allErrors = append(allErrors, errors.Errorf(errors.CodeNotAllowed, typeCtx, "unknown type", "Resource type %q is not supported", out.Type))
+		}
+	}
+	if ruleSet.ignoreClientID && !ruleSet.clientGeneratedID && out.ID != "" && MethodFromContext(ctx) == "POST" {
+		idCtx := rulecontext.WithPathString(ctx, "id")
+		addWarning(ctx, errors.Errorf(errors.CodeUnexpected, idCtx, "client-generated id ignored", "Client-generated id %q was ignored", out.ID))
+		out.ID = ""
+	}
+	if errs := ruleSet.evaluateID(ctx, out.ID); errs != nil {
+		allErrors = append(allErrors, errors.Unwrap(errs)...)
+	}
+	if errs := ruleSet.evaluateRequiredRelationships(ctx, out.Relationships); errs != nil {
+		allErrors = append(allErrors, errors.Unwrap(errs)...)
+	}
+	if errs := evaluateMetaHook(ctx, ruleSet.metaHook, out.Meta); errs != nil {
+		allErrors = append(allErrors, errors.Unwrap(errs)...)
+	}
+	if errs := ruleSet.evaluateRelationshipTypes(ctx, out.Relationships); errs != nil {
+		allErrors = append(allErrors, errors.Unwrap(errs)...)
+	}
+	if errs := ruleSet.evaluateRelationshipMeta(ctx, out.Relationships); errs != nil {
+		allErrors = append(allErrors, errors.Unwrap(errs)...)
+	}
+	if ruleSet.negotiatedExtensions != nil {
+		if errs := evaluateExtensionMembers(ctx, out.ExtensionMembers, ruleSet.negotiatedExtensions); errs != nil {
+			allErrors = append(allErrors, errors.Unwrap(errs)...)
+		}
+	}
+	if len(ruleSet.declaredExtensions) > 0 {
+		if errs := evaluateDeclaredExtensionMembers(ctx, out.ExtensionMembers, ruleSet.declaredExtensions); errs != nil {
+			allErrors = append(allErrors, errors.Unwrap(errs)...)
+		}
+	}
+	if errs := errors.Join(allErrors...); errs != nil {
+		return zero, errs
+	}
+	if ruleSet.typeResolver == nil {
+		out.Type = ruleSet.typeRuleSet.Value()
+	}
+	out.NameTransform = ruleSet.attributeNameTransform()
 	return out, nil
 }
 
+// attributeNameTransform returns the function that maps registered attribute names to document names,
+// or nil if the attributes rule set does not transform names (see AttributesRuleSet.WithNameTransform).
+func (ruleSet *DatumRuleSet[T]) attributeNameTransform() func(string) string {
+	if namer, ok := ruleSet.attributesRuleSet.(interface{ externalNames() func(string) string }); ok {
+		return namer.externalNames()
+	}
+	return nil
+}
+
+// evaluateObjectMembers returns a CodeType error for each of the named members that is present in input
+// but not an object. These members must be objects when present; null is not the same as absent, and an
+// array or scalar would otherwise fail to decode with an error that does not point at the member.
+func evaluateObjectMembers(ctx context.Context, input any, members ...string) errors.ValidationError {
+	inputMap, ok := input.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var allErrors []error
+	for _, member := range members {
+		value, ok := inputMap[member]
+		if !ok {
+			continue
+		}
+		memberCtx := rulecontext.WithPathString(ctx, member)
+		switch value.(type) {
+		case map[string]any:
+		case nil:
+			allErrors = append(allErrors, errors.Errorf(errors.CodeType, memberCtx, "object expected", "%s must be an object, not null", member))
+		default:
+			allErrors = append(allErrors, errors.Errorf(errors.CodeType, memberCtx, "object expected", "%s must be an object", member))
+		}
+	}
+	return errors.Join(allErrors...)
+}
+
+// evaluateTypeName checks a non-empty string type in input with TypeNameRule. An empty type is left to
+// the datum rules, since it may be implied. This runs before the type is compared with the expected type,
+// so a malformed type is reported as such.
+func evaluateTypeName(ctx context.Context, input any) errors.ValidationError {
+	inputMap, ok := input.(map[string]any)
+	if !ok {
+		return nil
+	}
+	typeName, ok := inputMap["type"].(string)
+	if !ok || typeName == "" {
+		return nil
+	}
+	return TypeNameRule.Evaluate(rulecontext.WithPathString(ctx, "type"), typeName)
+}
+
+// evaluateLid checks a lid present in input on POST requests with MemberNameRule, so an empty lid
+// is rejected rather than decoded as absent and the lid is safe to use as a key when resolving linkage.
+func evaluateLid(ctx context.Context, input any) errors.ValidationError {
+	inputMap, ok := input.(map[string]any)
+	if !ok || MethodFromContext(ctx) != "POST" {
+		return nil
+	}
+	lid, ok := inputMap["lid"].(string)
+	if !ok {
+		return nil
+	}
+	return MemberNameRule{}.Evaluate(rulecontext.WithPathString(ctx, "lid"), lid)
+}
+
+// evaluateTypeAlias accepts the type given to NewDatumRuleSet or any of the type aliases.
+func (ruleSet *DatumRuleSet[T]) evaluateTypeAlias(ctx context.Context, typeName string) errors.ValidationError {
+	if typeName == ruleSet.typeRuleSet.Value() {
+		return nil
+	}
+	for _, alias := range ruleSet.typeAliases {
+		if typeName == alias {
+			return nil
+		}
+	}
+	return errors.Errorf(errors.CodeNotAllowed, ctx, "unknown type", "Resource type %q is not supported", typeName)
+}
+
+// evaluateID checks the resource id against the request context.
+// POST requests may only include an id when client-generated ids are allowed.
+// When an endpoint id is set on the context for PATCH or DELETE requests, the resource id must match it,
+// and PATCH requests must include the id.
+func (ruleSet *DatumRuleSet[T]) evaluateID(ctx context.Context, id string) errors.ValidationError {
+	method := MethodFromContext(ctx)
+	idCtx := rulecontext.WithPathString(ctx, "id")
+
+	if method == "POST" {
+		if id != "" && !ruleSet.clientGeneratedID {
+			return errors.Errorf(errors.CodeForbidden, idCtx, "client-generated id forbidden", "Client-generated ids are not supported for this resource")
+		}
+		return nil
+	}
+
+	contextID := IdFromContext(ctx)
+	if contextID == "" || (method != "PATCH" && method != "DELETE") {
+		return nil
+	}
+
+	if id == "" {
+		if method == "PATCH" {
+			return errors.Errorf(errors.CodeRequired, idCtx, "id required", "Resource id is required when updating a resource")
+		}
+		return nil
+	}
+	if id != contextID {
+		return errors.Errorf(errors.CodeNotAllowed, idCtx, "id mismatch", "Resource id %q does not match the endpoint id %q", id, contextID)
+	}
+	return nil
+}
+
+// evaluateRequiredRelationships checks that every required relationship is present with non-null data on POST requests.
+func (ruleSet *DatumRuleSet[T]) evaluateRequiredRelationships(ctx context.Context, relationships map[string]Relationship) errors.ValidationError {
+	if len(ruleSet.requiredRelationships) == 0 || MethodFromContext(ctx) != "POST" {
+		return nil
+	}
+
+	var allErrors []error
+	relationshipsCtx := rulecontext.WithPathString(ctx, "relationships")
+	for _, relName := range ruleSet.requiredRelationships {
+		rel, ok := relationships[relName]
+		if ok && rel.Data != nil {
+			if _, isNil := rel.Data.(NilResourceLinkage); !isNil {
+				continue
+			}
+		}
+		relCtx := rulecontext.WithPathString(relationshipsCtx, relName)
+		allErrors = append(allErrors, errors.Errorf(errors.CodeRequired, relCtx, "relationship required", "Relationship %q is required", relName))
+	}
+	return errors.Join(allErrors...)
+}
+
+// evaluateRelationshipTypes checks that linkage in typed relationships refers to the expected resource type.
+func (ruleSet *DatumRuleSet[T]) evaluateRelationshipTypes(ctx context.Context, relationships map[string]Relationship) errors.ValidationError {
+	if len(ruleSet.relationshipTypes) == 0 || len(relationships) == 0 {
+		return nil
+	}
+
+	relNames := make([]string, 0, len(ruleSet.relationshipTypes))
+	for relName := range ruleSet.relationshipTypes {
+		relNames = append(relNames, relName)
+	}
+	sort.Strings(relNames)
+
+	var allErrors []error
+	relationshipsCtx := rulecontext.WithPathString(ctx, "relationships")
+	for _, relName := range relNames {
+		rel, ok := relationships[relName]
+		if !ok {
+			continue
+		}
+		expectedType := ruleSet.relationshipTypes[relName]
+		dataCtx := rulecontext.WithPathString(rulecontext.WithPathString(relationshipsCtx, relName), "data")
+
+		switch data := rel.Data.(type) {
+		case ResourceIdentifierLinkage:
+			if err := evaluateLinkageType(dataCtx, data, expectedType); err != nil {
+				allErrors = append(allErrors, err)
+			}
+		case ResourceLinkageCollection:
+			for i, linkage := range data {
+				itemCtx := rulecontext.WithPathString(dataCtx, strconv.Itoa(i))
+				if err := evaluateLinkageType(itemCtx, linkage, expectedType); err != nil {
+					allErrors = append(allErrors, err)
+				}
+			}
+		}
+	}
+	return errors.Join(allErrors...)
+}
+
+// evaluateRelationshipMeta applies the rule sets registered with WithRelationshipMeta and, with WithStrictMeta,
+// checks the member names of every relationship meta.
+func (ruleSet *DatumRuleSet[T]) evaluateRelationshipMeta(ctx context.Context, relationships map[string]Relationship) errors.ValidationError {
+	if (len(ruleSet.relationshipMeta) == 0 && !ruleSet.strictMeta) || len(relationships) == 0 {
+		return nil
+	}
+
+	relNames := make([]string, 0, len(relationships))
+	for relName := range relationships {
+		relNames = append(relNames, relName)
+	}
+	sort.Strings(relNames)
+
+	var allErrors []error
+	relationshipsCtx := rulecontext.WithPathString(ctx, "relationships")
+	for _, relName := range relNames {
+		rel := relationships[relName]
+		relCtx := rulecontext.WithPathString(relationshipsCtx, relName)
+		if ruleSet.strictMeta && rel.Meta != nil {
+			if errs := MetaMemberNamesRule.Evaluate(rulecontext.WithPathString(relCtx, "meta"), rel.Meta); errs != nil {
+				allErrors = append(allErrors, errors.Unwrap(errs)...)
+			}
+		}
+		if errs := evaluateMetaHook(relCtx, ruleSet.relationshipMeta[relName], rel.Meta); errs != nil {
+			allErrors = append(allErrors, errors.Unwrap(errs)...)
+		}
+	}
+	return errors.Join(allErrors...)
+}
+
+// evaluateLinkageType returns an error at the linkage type when it does not match the expected type.
+func evaluateLinkageType(ctx context.Context, linkage ResourceIdentifierLinkage, expectedType string) errors.ValidationError {
+	if linkage.Type == expectedType {
+		return nil
+	}
+	typeCtx := rulecontext.WithPathString(ctx, "type")
+	return errors.Errorf(errors.CodeNotAllowed, typeCtx, "unexpected relationship type", "Expected type %q, got %q", expectedType, linkage.Type)
+}
+
 // Evaluate validates a Datum value and returns any validation errors.
+// The value is validated in its JSON form, exactly as a resource object in a request body would be, so
+// members omitted when marshaling are treated as absent. Fields is ignored so every attribute is checked.
+// Attributes returned under registered names by an AttributesRuleSet name transform are accepted.
 func (ruleSet *DatumRuleSet[T]) Evaluate(ctx context.Context, value Datum[T]) errors.ValidationError {
-	_, err := ruleSet.Apply(ctx, value)
-	return err
+	value.Fields = nil
+	if transform := ruleSet.attributeNameTransform(); transform != nil {
+		// Attributes hold registered names, which documents do not accept; write them as Apply expects.
+		value.NameTransform = transform
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return errors.Errorf(errors.CodeEncoding, ctx, "resource encoding failed", "Resource object could not be encoded: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return errors.Errorf(errors.CodeEncoding, ctx, "resource encoding failed", "Resource object could not be encoded: %v", err)
+	}
+	_, errs := ruleSet.Apply(ctx, decoded)
+	return errs
 }
 
 // Any returns the rule set as rules.RuleSet[any] for use with generic validators.
@@ -2,6 +2,7 @@ package jsonapi_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"proto.zip/studio/jsonapi/pkg/jsonapi"
@@ -294,6 +295,9 @@ func TestDatumRuleSet_WithRequired(t *testing.T) {
 	}
 }
 
+// Requirements:
+// - Evaluate returns nil for a valid datum.
+// - Evaluate returns the attribute error for an invalid datum, at its path.
 func TestDatumRuleSet_Evaluate(t *testing.T) {
 	type testDatum struct {
 		Name string
@@ -303,12 +307,52 @@ func TestDatumRuleSet_Evaluate(t *testing.T) {
 		WithKey("Name", rules.String().WithMinLen(3).Any())
 
 	ruleSet := jsonapi.NewDatumRuleSet[testDatum]("tests", attributesRuleSet)
+	ctx := context.Background()
+
+	valid := jsonapi.Datum[testDatum]{ID: "1", Type: "tests", Attributes: testDatum{Name: "Alice"}}
+	if errs := ruleSet.Evaluate(ctx, valid); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	invalid := jsonapi.Datum[testDatum]{ID: "1", Type: "tests", Attributes: testDatum{Name: "Al"}}
+	errs := ruleSet.Evaluate(ctx, invalid)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	unwrapped := errors.Unwrap(errs)
+	if len(unwrapped) != 1 {
+		t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+	}
+	ve := unwrapped[0].(errors.ValidationError)
+	if ve.Code() != errors.CodeMin {
+		t.Errorf("Expected code %s, got: %s", errors.CodeMin, ve.Code())
+	}
+	if ve.Path() != "/attributes/Name" {
+		t.Errorf(`Expected path to be "/attributes/Name", got: "%s"`, ve.Path())
+	}
+}
+
+// Requirements:
+// - Evaluate accepts the datum returned by Apply when the attributes rule set transforms names.
+func TestDatumRuleSet_EvaluateNameTransform(t *testing.T) {
+	attributes := jsonapi.Attributes().
+		WithNameTransform(func(name string) string { return strings.ReplaceAll(name, "_", "-") }).
+		WithKey("first_name", rules.String().WithMinLen(1).Any())
+	ruleSet := jsonapi.NewDatumRuleSet[map[string]any]("people", attributes)
+	ctx := context.Background()
+
+	out, errs := ruleSet.Apply(ctx, map[string]any{"type": "people", "id": "1", "attributes": map[string]any{"first-name": "Ada"}})
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if errs := ruleSet.Evaluate(ctx, out); errs != nil {
+		t.Errorf("Expected Evaluate errors to be nil, got: %s", errs)
+	}
 
-	// Evaluate method exists and implements the RuleSet interface
-	// Note: Evaluate is provided for interface compatibility but may have
-	// limitations when used directly with struct values. Use Apply() for
-	// full functionality with JSON strings or maps.
-	_ = ruleSet.Evaluate
+	out.Attributes["first_name"] = ""
+	if errs := ruleSet.Evaluate(ctx, out); errs == nil {
+		t.Error("Expected Evaluate errors for an empty first_name, got: nil")
+	}
 }
 
 func TestDatumRuleSet_String(t *testing.T) {
@@ -353,3 +397,146 @@ func TestDatumRuleSet_WithMetaWithNilAndErrorConfig(t *testing.T) {
 		t.Fatalf("Apply via Any: %s", errs)
 	}
 }
+
+// Requirements:
+// - PATCH with a matching id passes.
+// - PATCH with a mismatching id errors with CodeNotAllowed at /id.
+// - PATCH without an id errors with CodeRequired at /id.
+// - Other methods are not affected.
+func TestDatumRuleSet_ContextID(t *testing.T) {
+	ruleSet := jsonapi.NewDatumRuleSet[map[string]any]("tests", rules.StringMap[any]().WithUnknown())
+	ctx := jsonapi.WithId(jsonapi.WithMethod(context.Background(), "PATCH"), "abc")
+
+	if _, errs := ruleSet.Apply(ctx, `{"id": "abc", "attributes": {}}`); errs != nil {
+		t.Errorf("Expected matching id to pass, got: %s", errs)
+	}
+
+	tests := []struct {
+		name string
+		json string
+		code errors.ErrorCode
+	}{
+		{"mismatch", `{"id": "xyz", "attributes": {}}`, errors.CodeNotAllowed},
+		{"absent", `{"attributes": {}}`, errors.CodeRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := ruleSet.Apply(ctx, tt.json)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			unwrapped := errors.Unwrap(errs)
+			if len(unwrapped) != 1 {
+				t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+			}
+			ve := unwrapped[0].(errors.ValidationError)
+			if ve.Code() != tt.code {
+				t.Errorf("Expected code %s, got: %s", tt.code, ve.Code())
+			}
+			if ve.Path() != "/id" {
+				t.Errorf(`Expected path to be "/id", got: "%s"`, ve.Path())
+			}
+		})
+	}
+
+	// The endpoint id is only enforced for PATCH and DELETE.
+	getCtx := jsonapi.WithId(jsonapi.WithMethod(context.Background(), "GET"), "abc")
+	if _, errs := ruleSet.Apply(getCtx, `{"id": "xyz", "attributes": {}}`); errs != nil {
+		t.Errorf("Expected GET to ignore the endpoint id, got: %s", errs)
+	}
+}
+
+// Requirements:
+// - POST with an id is forbidden at /id by default.
+// - POST with an id passes when client-generated ids are allowed.
+// - POST with a lid always passes.
+func TestDatumRuleSet_WithClientGeneratedID(t *testing.T) {
+	ruleSet := jsonapi.NewDatumRuleSet[map[string]any]("tests", rules.StringMap[any]().WithUnknown())
+	ctx := jsonapi.WithMethod(context.Background(), "POST")
+
+	_, errs := ruleSet.Apply(ctx, `{"id": "abc", "attributes": {}}`)
+	if errs == nil {
+		t.Fatal("Expected client-generated id to be rejected by default")
+	}
+	unwrapped := errors.Unwrap(errs)
+	if len(unwrapped) != 1 {
+		t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+	}
+	ve := unwrapped[0].(errors.ValidationError)
+	if ve.Code() != errors.CodeForbidden {
+		t.Errorf("Expected code %s, got: %s", errors.CodeForbidden, ve.Code())
+	}
+	if ve.Path() != "/id" {
+		t.Errorf(`Expected path to be "/id", got: "%s"`, ve.Path())
+	}
+
+	if _, errs := ruleSet.WithClientGeneratedID(true).Apply(ctx, `{"id": "abc", "attributes": {}}`); errs != nil {
+		t.Errorf("Expected client-generated id to be accepted when enabled, got: %s", errs)
+	}
+
+	if _, errs := ruleSet.Apply(ctx, `{"lid": "local-1", "type": "tests", "attributes": {}}`); errs != nil {
+		t.Errorf("Expected lid to be accepted, got: %s", errs)
+	}
+}
+
+// Requirements:
+// - A lid without id and type errors with CodeRequired at /type.
+// - A lid with type passes.
+func TestDatumRuleSet_LidRequiresType(t *testing.T) {
+	ruleSet := jsonapi.NewDatumRuleSet[map[string]any]("tests", rules.StringMap[any]().WithUnknown())
+	ctx := jsonapi.WithMethod(context.Background(), "POST")
+
+	_, errs := ruleSet.Apply(ctx, `{"lid": "local-1", "attributes": {}}`)
+	if errs == nil {
+		t.Fatal("Expected missing type with lid to be rejected")
+	}
+	unwrapped := errors.Unwrap(errs)
+	if len(unwrapped) != 1 {
+		t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+	}
+	ve := unwrapped[0].(errors.ValidationError)
+	if ve.Code() != errors.CodeRequired {
+		t.Errorf("Expected code %s, got: %s", errors.CodeRequired, ve.Code())
+	}
+	if ve.Path() != "/type" {
+		t.Errorf(`Expected path to be "/type", got: "%s"`, ve.Path())
+	}
+
+	if _, errs := ruleSet.Apply(ctx, `{"lid": "local-1", "type": "tests", "attributes": {}}`); errs != nil {
+		t.Errorf("Expected lid with type to pass, got: %s", errs)
+	}
+}
+
+// Requirements:
+// - With WithIgnoredClientID, a POST with an id succeeds and the id is discarded.
+// - A warning is recorded at /id in the context.
+// - A POST without an id records no warning.
+func TestDatumRuleSet_WithIgnoredClientID(t *testing.T) {
+	ruleSet := jsonapi.NewDatumRuleSet[map[string]any]("tests", rules.StringMap[any]().WithUnknown()).
+		WithIgnoredClientID()
+
+	ctx := jsonapi.WithWarnings(jsonapi.WithMethod(context.Background(), "POST"))
+	out, errs := ruleSet.Apply(ctx, `{"id": "abc", "type": "tests", "attributes": {}}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if out.ID != "" {
+		t.Errorf("Expected id to be discarded, got: %q", out.ID)
+	}
+
+	warnings := errors.Unwrap(jsonapi.WarningsFromContext(ctx))
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got: %d", len(warnings))
+	}
+	if path := warnings[0].(errors.ValidationError).Path(); path != "/id" {
+		t.Errorf(`Expected path to be "/id", got: "%s"`, path)
+	}
+
+	ctx = jsonapi.WithWarnings(jsonapi.WithMethod(context.Background(), "POST"))
+	if _, errs := ruleSet.Apply(ctx, `{"type": "tests", "attributes": {}}`); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+	if warnings := jsonapi.WarningsFromContext(ctx); warnings != nil {
+		t.Errorf("Expected no warnings, got: %s", warnings)
+	}
+}
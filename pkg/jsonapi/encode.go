@@ -0,0 +1,43 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// EncodeResource returns a request document ({"data": {...}}) for an existing resource, such as the body of
+// a PATCH request. Send it with Content-Type MediaTypeJSONAPI. Relationships are omitted when rels is empty.
+func EncodeResource[T any](typeName, id string, attrs T, rels map[string]Relationship) ([]byte, error) {
+	if id == "" {
+		return nil, fmt.Errorf("jsonapi: resource id is required; use EncodeNewResource for resources without an id")
+	}
+	return encodeResource(Datum[T]{ID: id, Type: typeName, Attributes: attrs, Relationships: rels})
+}
+
+// EncodeNewResource returns a request document for a resource that does not have an id yet, such as the body
+// of a POST request. The id member is omitted.
+func EncodeNewResource[T any](typeName string, attrs T, rels map[string]Relationship) ([]byte, error) {
+	data, err := encodeResource(Datum[T]{Type: typeName, Attributes: attrs, Relationships: rels})
+	if err != nil {
+		return nil, err
+	}
+
+	// Datum always writes an id unless it has a lid, so remove the empty one.
+	var document struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	delete(document.Data, "id")
+	return json.Marshal(document)
+}
+
+// encodeResource checks the type name and marshals datum as the primary data of a document.
+func encodeResource[T any](datum Datum[T]) ([]byte, error) {
+	if err := TypeNameRule.Evaluate(context.Background(), datum.Type); err != nil {
+		return nil, fmt.Errorf("jsonapi: invalid resource type %q: %w", datum.Type, err)
+	}
+	return json.Marshal(SingleDatumEnvelope[T]{Data: datum})
+}
@@ -0,0 +1,73 @@
+package jsonapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+type encodeAttributes struct {
+	Title string `json:"title"`
+}
+
+// Requirements:
+// - EncodeResource produces {"data": {...}} with the type, id, attributes, and relationships.
+// - The document validates as a PATCH body.
+// - An empty id or an invalid type is an error.
+func TestEncodeResource(t *testing.T) {
+	rels := map[string]jsonapi.Relationship{
+		"author": {Data: jsonapi.ResourceIdentifierLinkage{Type: "people", ID: "9"}},
+	}
+	data, err := jsonapi.EncodeResource("articles", "1", encodeAttributes{Title: "Hello"}, rels)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got: %s", err)
+	}
+	want := `{"data":{"type":"articles","id":"1","attributes":{"title":"Hello"},"relationships":{"author":{"data":{"type":"people","id":"9"}}}}}`
+	if !jsonEqual(want, string(data)) {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithUnknownRelationships()
+	ctx := jsonapi.WithId(jsonapi.WithMethod(context.Background(), http.MethodPatch), "1")
+	if _, errs := ruleSet.Apply(ctx, string(data)); errs != nil {
+		t.Errorf("Expected the encoded document to validate, got: %s", errs)
+	}
+
+	if _, err := jsonapi.EncodeResource("articles", "", encodeAttributes{}, nil); err == nil {
+		t.Error("Expected an error for an empty id")
+	}
+	if _, err := jsonapi.EncodeResource("arti cles", "1", encodeAttributes{}, nil); err == nil {
+		t.Error("Expected an error for an invalid type")
+	}
+}
+
+// Requirements:
+// - EncodeNewResource omits the id member.
+// - The document validates as a POST body.
+func TestEncodeNewResource(t *testing.T) {
+	data, err := jsonapi.EncodeNewResource("articles", encodeAttributes{Title: "Hello"}, nil)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got: %s", err)
+	}
+	var document map[string]map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Expected unmarshal error to be nil, got: %s", err)
+	}
+	if _, ok := document["data"]["id"]; ok {
+		t.Errorf("Expected no id member, got %s", data)
+	}
+	if document["data"]["type"] != "articles" {
+		t.Errorf("Expected type articles, got %s", data)
+	}
+
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown())
+	ctx := jsonapi.WithMethod(context.Background(), http.MethodPost)
+	if _, errs := ruleSet.Apply(ctx, string(data)); errs != nil {
+		t.Errorf("Expected the encoded document to validate, got: %s", errs)
+	}
+}
@@ -0,0 +1,194 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// errorObjectMembers are the members defined for a JSON:API error object.
+var errorObjectMembers = []string{"id", "links", "status", "code", "title", "detail", "source", "meta"}
+
+var errorStatusPattern = regexp.MustCompile(`^[0-9]{3}$`)
+
+// errorResponseCast validates an error document (JSON string, []byte, or decoded map) and decodes it into an ErrorResponse.
+func errorResponseCast(ctx context.Context, value any) (ErrorResponse, errors.ValidationError) {
+	var zero ErrorResponse
+
+	switch v := value.(type) {
+	case []byte:
+		return errorResponseCast(ctx, string(v))
+	case string:
+		var decoded any
+		if err := json.Unmarshal([]byte(v), &decoded); err != nil {
+			return zero, errors.Join(&jsonAPIErrorWrapper{err: MalformedJSONError([]byte(v))})
+		}
+		value = decoded
+	}
+
+	document, ok := value.(map[string]any)
+	if !ok {
+		return zero, errors.Errorf(errors.CodeType, ctx, "object", "Error document must be an object")
+	}
+	errorsCtx := rulecontext.WithPathString(ctx, "errors")
+	items, ok := document["errors"]
+	if !ok {
+		return zero, errors.Errorf(errors.CodeRequired, errorsCtx, "errors required", "Error document must contain an errors member")
+	}
+	list, ok := items.([]any)
+	if !ok {
+		return zero, errors.Errorf(errors.CodeType, errorsCtx, "array", "errors must be an array")
+	}
+
+	var allErrors []error
+	for i, item := range list {
+		itemCtx := rulecontext.WithPathString(errorsCtx, strconv.Itoa(i))
+		if err := evaluateErrorObject(itemCtx, item); err != nil {
+			allErrors = append(allErrors, errors.Unwrap(err)...)
+		}
+	}
+	if errs := errors.Join(allErrors...); errs != nil {
+		return zero, errs
+	}
+
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return zero, errors.Errorf(errors.CodeEncoding, ctx, "encoding failed", "Error document could not be encoded: %v", err)
+	}
+	var out ErrorResponse
+	if err := json.Unmarshal(encoded, &out); err != nil {
+		return zero, errors.Errorf(errors.CodeType, ctx, "error document", "Error document has invalid members: %v", err)
+	}
+	return out, nil
+}
+
+// evaluateErrorObject checks that an error object has at least one defined member and a 3-digit string status.
+func evaluateErrorObject(ctx context.Context, item any) errors.ValidationError {
+	obj, ok := item.(map[string]any)
+	if !ok {
+		return errors.Errorf(errors.CodeType, ctx, "object", "Error object must be an object")
+	}
+
+	hasMember := false
+	for _, member := range errorObjectMembers {
+		if _, ok := obj[member]; ok {
+			hasMember = true
+			break
+		}
+	}
+	if !hasMember {
+		return errors.Errorf(errors.CodeRequired, ctx, "error member required", "Error object must contain at least one of %v", errorObjectMembers)
+	}
+
+	if status, ok := obj["status"]; ok {
+		statusCtx := rulecontext.WithPathString(ctx, "status")
+		str, ok := status.(string)
+		if !ok {
+			return errors.Errorf(errors.CodeType, statusCtx, "string", "status must be a string")
+		}
+		if !errorStatusPattern.MatchString(str) {
+			return errors.Errorf(errors.CodePattern, statusCtx, "invalid status", "status must be a 3-digit HTTP status code, got %q", str)
+		}
+	}
+	return nil
+}
+
+// ErrorResponseRuleSet validates an incoming JSON:API error document and decodes it into an ErrorResponse.
+// Each error object must have at least one member defined by the spec, and status must be a 3-digit string.
+var ErrorResponseRuleSet rules.RuleSet[ErrorResponse] = rules.Interface[ErrorResponse]().WithCast(errorResponseCast)
+
+// ParseErrorResponse parses and validates a JSON:API error document, such as one returned by a server.
+// The returned error is an errors.ValidationError describing every problem found.
+func ParseErrorResponse(data []byte) (*ErrorResponse, error) {
+	out, errs := ErrorResponseRuleSet.Apply(context.Background(), data)
+	if errs != nil {
+		return nil, errs
+	}
+	return &out, nil
+}
+
+// ErrorDocBuilder assembles an ErrorResponse one error at a time. Each method returns a new builder.
+type ErrorDocBuilder struct {
+	errors []Error
+}
+
+// NewErrorDoc returns an empty error document builder.
+func NewErrorDoc() *ErrorDocBuilder {
+	return &ErrorDocBuilder{}
+}
+
+// withError returns a copy of the builder with e appended.
+func (b *ErrorDocBuilder) withError(e Error) *ErrorDocBuilder {
+	out := make([]Error, len(b.errors), len(b.errors)+1)
+	copy(out, b.errors)
+	return &ErrorDocBuilder{errors: append(out, e)}
+}
+
+// PointerError adds an error whose source is a JSON Pointer into the request document.
+func (b *ErrorDocBuilder) PointerError(status int, code, title, detail, pointer string) *ErrorDocBuilder {
+	return b.withError(Error{Status: strconv.Itoa(status), Code: code, Title: title, Detail: detail, Source: &Source{Pointer: pointer}})
+}
+
+// ParameterError adds an error whose source is a query parameter.
+func (b *ErrorDocBuilder) ParameterError(status int, code, title, detail, parameter string) *ErrorDocBuilder {
+	return b.withError(Error{Status: strconv.Itoa(status), Code: code, Title: title, Detail: detail, Source: &Source{Parameter: parameter}})
+}
+
+// HeaderError adds an error whose source is a request header.
+func (b *ErrorDocBuilder) HeaderError(status int, code, title, detail, header string) *ErrorDocBuilder {
+	return b.withError(Error{Status: strconv.Itoa(status), Code: code, Title: title, Detail: detail, Source: &Source{Header: header}})
+}
+
+// Build returns the error document. Use WriteErrors to send its errors.
+func (b *ErrorDocBuilder) Build() ErrorResponse {
+	return ErrorResponse{Errors: append([]Error(nil), b.errors...)}
+}
+
+// AttributeError returns an error whose source points at the named attribute of the primary resource
+// (/data/attributes/<field>), for mapping domain validation failures to the request document.
+// The field name is escaped as a JSON Pointer token.
+func AttributeError(field, status, code, title, detail string) Error {
+	return Error{
+		Status: status,
+		Code:   code,
+		Title:  title,
+		Detail: detail,
+		Source: &Source{Pointer: "/data/attributes/" + jsonPointerEscaper.Replace(field)},
+	}
+}
+
+// statusError returns an error with the given HTTP status, its standard status text as the title, and detail.
+func statusError(status int, detail string) Error {
+	return Error{Status: strconv.Itoa(status), Title: http.StatusText(status), Detail: detail}
+}
+
+// BadRequest returns a 400 Bad Request error with the given detail.
+func BadRequest(detail string) Error {
+	return statusError(http.StatusBadRequest, detail)
+}
+
+// NotFound returns a 404 Not Found error with the given detail.
+func NotFound(detail string) Error {
+	return statusError(http.StatusNotFound, detail)
+}
+
+// Conflict returns a 409 Conflict error with the given detail, e.g. for a type mismatch or a duplicate client-generated id.
+func Conflict(detail string) Error {
+	return statusError(http.StatusConflict, detail)
+}
+
+// UnprocessableEntity returns a 422 Unprocessable Entity error with the given detail.
+func UnprocessableEntity(detail string) Error {
+	return statusError(http.StatusUnprocessableEntity, detail)
+}
+
+// InternalError returns a 500 Internal Server Error error with the given detail.
+func InternalError(detail string) Error {
+	return statusError(http.StatusInternalServerError, detail)
+}
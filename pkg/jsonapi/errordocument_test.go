@@ -0,0 +1,150 @@
+package jsonapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/errors"
+)
+
+// Requirements:
+// - A valid error document is decoded into an ErrorResponse.
+// - Error objects without any defined member are rejected.
+// - Status must be a 3-digit string.
+// - Missing errors and malformed JSON are rejected.
+func TestParseErrorResponse(t *testing.T) {
+	doc := `{"errors": [
+		{"status": "422", "code": "REQUIRED", "title": "Required", "source": {"pointer": "/data/attributes/title"}},
+		{"detail": "Something happened", "meta": {"requestId": "abc"}}
+	]}`
+	out, err := jsonapi.ParseErrorResponse([]byte(doc))
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got: %s", err)
+	}
+	if len(out.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got %d", len(out.Errors))
+	}
+	if out.Errors[0].Source == nil || out.Errors[0].Source.Pointer != "/data/attributes/title" {
+		t.Errorf("Expected source pointer to be decoded, got %+v", out.Errors[0].Source)
+	}
+	if out.Errors[1].Meta == nil || (*out.Errors[1].Meta)["requestId"] != "abc" {
+		t.Errorf("Expected meta to be decoded, got %+v", out.Errors[1].Meta)
+	}
+
+	tests := []struct {
+		name string
+		doc  string
+		path string
+	}{
+		{"empty error object", `{"errors": [{"status": "400"}, {}]}`, "/errors/1"},
+		{"numeric status", `{"errors": [{"status": 400}]}`, "/errors/0/status"},
+		{"short status", `{"errors": [{"status": "40"}]}`, "/errors/0/status"},
+		{"missing errors", `{"meta": {}}`, "/errors"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jsonapi.ParseErrorResponse([]byte(tt.doc))
+			if err == nil {
+				t.Fatal("Expected error to not be nil")
+			}
+			unwrapped := errors.Unwrap(err.(errors.ValidationError))
+			if len(unwrapped) != 1 {
+				t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+			}
+			if path := unwrapped[0].(errors.ValidationError).Path(); path != tt.path {
+				t.Errorf(`Expected path to be "%s", got: "%s"`, tt.path, path)
+			}
+		})
+	}
+
+	_, err = jsonapi.ParseErrorResponse([]byte(`{"errors": [`))
+	if err == nil {
+		t.Fatal("Expected malformed JSON to be rejected")
+	}
+	list := jsonapi.ErrorsFromValidationError(err.(errors.ValidationError), jsonapi.SourcePointer)
+	if len(list) != 1 || list[0].Code != string(jsonapi.CodeMalformedJSON) {
+		t.Errorf("Expected a single malformed JSON error, got %+v", list)
+	}
+}
+
+// Requirements:
+// - Each helper sets the matching source member only.
+// - The built document is valid and serializes every error in order.
+// - Builders are immutable.
+func TestNewErrorDoc(t *testing.T) {
+	base := jsonapi.NewErrorDoc()
+	doc := base.
+		PointerError(422, "required", "Required", "title is required", "/data/attributes/title").
+		ParameterError(400, "invalid", "Invalid parameter", "unknown sort field", "sort").
+		HeaderError(406, "not_acceptable", "Not acceptable", "unsupported media type", "Accept").
+		Build()
+
+	if len(base.Build().Errors) != 0 {
+		t.Errorf("Expected the base builder to stay empty, got %d errors", len(base.Build().Errors))
+	}
+	if len(doc.Errors) != 3 {
+		t.Fatalf("Expected 3 errors, got %d", len(doc.Errors))
+	}
+	if err := doc.Validate(); err != nil {
+		t.Errorf("Expected errors to be nil, got: %s", err)
+	}
+
+	want := []jsonapi.Source{{Pointer: "/data/attributes/title"}, {Parameter: "sort"}, {Header: "Accept"}}
+	statuses := []string{"422", "400", "406"}
+	for i, e := range doc.Errors {
+		if e.Source == nil || *e.Source != want[i] {
+			t.Errorf("errors[%d]: expected source %+v, got %+v", i, want[i], e.Source)
+		}
+		if e.Status != statuses[i] {
+			t.Errorf("errors[%d]: expected status %s, got %s", i, statuses[i], e.Status)
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Expected marshal error to be nil, got: %s", err)
+	}
+	if _, err := jsonapi.ParseErrorResponse(data); err != nil {
+		t.Errorf("Expected the built document to parse, got: %s", err)
+	}
+}
+
+// Requirements:
+// - The pointer targets /data/attributes/<field>.
+// - "~" and "/" in the field name are escaped per RFC 6901.
+func TestAttributeError(t *testing.T) {
+	e := jsonapi.AttributeError("title", "422", "too_short", "Too short", "title must be at least 3 characters")
+	if e.Source == nil || e.Source.Pointer != "/data/attributes/title" {
+		t.Errorf("Expected pointer /data/attributes/title, got %+v", e.Source)
+	}
+	if e.Status != "422" || e.Code != "too_short" || e.Title != "Too short" || e.Detail == "" {
+		t.Errorf("Expected the given status, code, title, and detail, got %+v", e)
+	}
+
+	e = jsonapi.AttributeError("a/b~c", "422", "", "Invalid", "")
+	if e.Source == nil || e.Source.Pointer != "/data/attributes/a~1b~0c" {
+		t.Errorf("Expected pointer /data/attributes/a~1b~0c, got %+v", e.Source)
+	}
+}
+
+// Requirements:
+// - Each constructor sets its status, the standard status text as title, and the detail.
+func TestStatusErrorConstructors(t *testing.T) {
+	tests := []struct {
+		err    jsonapi.Error
+		status string
+		title  string
+	}{
+		{jsonapi.BadRequest("bad"), "400", "Bad Request"},
+		{jsonapi.NotFound("bad"), "404", "Not Found"},
+		{jsonapi.Conflict("bad"), "409", "Conflict"},
+		{jsonapi.UnprocessableEntity("bad"), "422", "Unprocessable Entity"},
+		{jsonapi.InternalError("bad"), "500", "Internal Server Error"},
+	}
+	for _, tt := range tests {
+		if tt.err.Status != tt.status || tt.err.Title != tt.title || tt.err.Detail != "bad" {
+			t.Errorf("Expected status %s, title %q, and detail %q, got %+v", tt.status, tt.title, "bad", tt.err)
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package jsonapi
 
 import (
+	"context"
+	"sort"
+	"strconv"
 	"strings"
 
 	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
 )
 
 // ErrorSourceKind is the type of JSON:API error source (pointer, parameter, or header).
@@ -18,6 +22,17 @@ const (
 	SourceHeader ErrorSourceKind = "header"
 )
 
+// CodeUnsupportedMediaType is the error code for Content-Type negotiation failures (wrong media type or
+// disallowed media type parameters). Errors with this code are reported with HTTP status 415.
+const CodeUnsupportedMediaType errors.ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+
+// CodeMalformedJSON is the error code for request bodies that are not valid JSON.
+const CodeMalformedJSON errors.ErrorCode = "MALFORMED_JSON"
+
+// CodeCanceled is the error code for validation abandoned because the request context was canceled
+// or its deadline passed. Errors with this code are reported with HTTP status 503.
+const CodeCanceled errors.ErrorCode = "CANCELED"
+
 // jsonAPIErrorWrapper wraps *Error to implement errors.ValidationError without
 // method/field name conflicts (Error has fields Code and Meta).
 type jsonAPIErrorWrapper struct{ err *Error }
@@ -134,6 +149,43 @@ type ErrorResponse struct {
 	Errors []Error `json:"errors,omitempty"`
 }
 
+// Validate checks the errors for problems that would make the response invalid JSON:API.
+// An error source may only identify one of pointer, parameter, or header; errors are reported at
+// the offending source (e.g. /errors/0/source).
+func (r ErrorResponse) Validate() errors.ValidationError {
+	errorsCtx := rulecontext.WithPathString(context.Background(), "errors")
+	var allErrors []error
+	for i, e := range r.Errors {
+		if e.Source == nil || e.Source.kinds() <= 1 {
+			continue
+		}
+		sourceCtx := rulecontext.WithPathString(rulecontext.WithPathString(errorsCtx, strconv.Itoa(i)), "source")
+		allErrors = append(allErrors, errors.Errorf(errors.CodeNotAllowed, sourceCtx, "multiple error sources", "Error source must set only one of pointer, parameter, or header"))
+	}
+	return errors.Join(allErrors...)
+}
+
+// kinds returns the number of source fields that are set.
+func (s *Source) kinds() int {
+	n := 0
+	for _, v := range []string{s.Pointer, s.Parameter, s.Header} {
+		if v != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// NewSource returns an error source for the given pointer, parameter, or header. At most one may be set,
+// since a source identifies a single origin of the error; otherwise a CodeNotAllowed error is returned.
+func NewSource(pointer, parameter, header string) (*Source, errors.ValidationError) {
+	s := &Source{Pointer: pointer, Parameter: parameter, Header: header}
+	if s.kinds() > 1 {
+		return nil, errors.Errorf(errors.CodeNotAllowed, context.Background(), "multiple error sources", "Error source must set only one of pointer, parameter, or header")
+	}
+	return s, nil
+}
+
 // ValidationError interface methods (errors.ValidationError).
 
 // jsonAPIErrorHolder is used to extract *Error from our wrapper in ErrorsFromCollection.
@@ -158,10 +210,16 @@ func queryParamName(path string) string {
 // ErrorFromValidationError builds a JSON:API Error from a ValidationError.
 // kind selects which source field to set: SourcePointer (body), SourceParameter (query), or SourceHeader.
 // When kind is SourcePointer, the path is serialized with JSON Pointer (RFC 6901) per JSON:API; other kinds use the default path.
-// Query string errors use HTTP status 400 per JSON:API; body validation errors use 422.
+// Errors about the whole document (e.g. a missing top-level member) have no source; errors about a
+// conflicting top-level member point at that member (e.g. /errors when data and errors coexist).
+// Query string and header errors use HTTP status 400 per JSON:API; body validation errors use 422.
+// Errors with CodeUnsupportedMediaType use 415.
 func ErrorFromValidationError(ve errors.ValidationError, kind ErrorSourceKind) *Error {
 	status := "422"
-	if kind == SourceParameter {
+	switch {
+	case ve.Code() == CodeUnsupportedMediaType:
+		status = "415"
+	case kind == SourceParameter || kind == SourceHeader:
 		status = "400"
 	}
 	e := &Error{
@@ -186,6 +244,11 @@ func ErrorFromValidationError(ve errors.ValidationError, kind ErrorSourceKind) *
 	case SourcePointer:
 		// JSON:API source.pointer MUST be a JSON Pointer [RFC6901].
 		path = ve.PathAs(jsonPointerSerializer)
+		if path == "/" {
+			// "/" points at a member with an empty name, not the document; errors about the whole
+			// document are reported without a source.
+			path = ""
+		}
 	default:
 		path = ve.Path()
 	}
@@ -214,6 +277,10 @@ func ToJSONAPIErrors(err errors.ValidationError, kind ErrorSourceKind) errors.Va
 	}
 	var out []error
 	for _, e := range unwrapped {
+		if canceled, ok := e.(*canceledError); ok {
+			out = append(out, canceled)
+			continue
+		}
 		ve := e.(errors.ValidationError)
 		out = append(out, &jsonAPIErrorWrapper{err: ErrorFromValidationError(ve, kind)})
 	}
@@ -235,11 +302,89 @@ func ErrorsFromValidationError(err errors.ValidationError, kind ErrorSourceKind)
 		if !ok {
 			continue
 		}
-		if h, ok := ve.(jsonAPIErrorHolder); ok {
-			out = append(out, *h.JSONAPIError())
-		} else {
-			out = append(out, *ErrorFromValidationError(ve, kind))
+		out = append(out, jsonAPIErrorFromValidationError(ve, kind))
+	}
+	sortErrors(out)
+	return out
+}
+
+// ErrorsFromValidationErrorVerified is like ErrorsFromValidationError but checks that each source.pointer
+// references a value that exists in doc, the request document decoded with encoding/json. Pointers that
+// do not resolve are dropped, as JSON:API requires a pointer to reference an existing value; the error
+// itself is kept without a source. This catches rules whose path context has drifted from the document.
+func ErrorsFromValidationErrorVerified(err errors.ValidationError, kind ErrorSourceKind, doc any) []Error {
+	out := ErrorsFromValidationError(err, kind)
+	for i := range out {
+		if out[i].Source == nil || out[i].Source.Pointer == "" {
+			continue
+		}
+		if !jsonPointerResolves(doc, out[i].Source.Pointer) {
+			out[i].Source = nil
+		}
+	}
+	sortErrors(out)
+	return out
+}
+
+// sortErrors orders errors by source (pointer, parameter, then header) and then by code so responses are
+// deterministic regardless of the order in which rules ran. Errors without a source sort first.
+func sortErrors(list []Error) {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := sourceSortKey(list[i].Source), sourceSortKey(list[j].Source)
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return list[i].Code < list[j].Code
+	})
+}
+
+// sourceSortKey returns the source fields used to order errors.
+func sourceSortKey(s *Source) [3]string {
+	if s == nil {
+		return [3]string{}
+	}
+	return [3]string{s.Pointer, s.Parameter, s.Header}
+}
+
+// jsonAPIErrorFromValidationError returns the JSON:API Error for ve, reusing it if ve already wraps one.
+func jsonAPIErrorFromValidationError(ve errors.ValidationError, kind ErrorSourceKind) Error {
+	if h, ok := ve.(jsonAPIErrorHolder); ok {
+		return *h.JSONAPIError()
+	}
+	return *ErrorFromValidationError(ve, kind)
+}
+
+// MessageResolver returns a localized title and detail for an error code and its parameters.
+// An empty title or detail keeps the original text.
+type MessageResolver func(code string, params []any) (title, detail string)
+
+// ErrorsFromValidationErrorLocalized is like ErrorsFromValidationError but replaces each error's title and
+// detail with the text returned by resolver. Source, status, links, and meta are preserved.
+func ErrorsFromValidationErrorLocalized(err errors.ValidationError, kind ErrorSourceKind, resolver MessageResolver) []Error {
+	unwrapped := errors.Unwrap(err)
+	if len(unwrapped) == 0 {
+		return nil
+	}
+	out := make([]Error, 0, len(unwrapped))
+	for _, e := range unwrapped {
+		ve, ok := e.(errors.ValidationError)
+		if !ok {
+			continue
+		}
+		jsonErr := jsonAPIErrorFromValidationError(ve, kind)
+		if resolver != nil {
+			title, detail := resolver(string(ve.Code()), ve.Params())
+			if title != "" {
+				jsonErr.Title = title
+			}
+			if detail != "" {
+				jsonErr.Detail = detail
+			}
 		}
+		out = append(out, jsonErr)
 	}
+	sortErrors(out)
 	return out
 }
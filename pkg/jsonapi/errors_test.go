@@ -520,3 +520,146 @@ func TestError_SerializationOmitsEmptyOptionalFields(t *testing.T) {
 		}
 	})
 }
+
+// Requirements:
+// - Errors with a single source, or no source, are valid.
+// - A source with both pointer and parameter is flagged at its index.
+func TestErrorResponse_ValidateSource(t *testing.T) {
+	valid := ErrorResponse{Errors: []Error{
+		{Status: "422", Source: &Source{Pointer: "/data/attributes/title"}},
+		{Status: "400", Source: &Source{Parameter: "sort"}},
+		{Status: "500"},
+	}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected errors to be nil, got: %s", err)
+	}
+
+	invalid := ErrorResponse{Errors: []Error{
+		{Status: "400", Source: &Source{Header: "Accept"}},
+		{Status: "400", Source: &Source{Pointer: "/data", Parameter: "include"}},
+	}}
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatal("Expected error for a source with both pointer and parameter")
+	}
+	unwrapped := errors.Unwrap(err)
+	if len(unwrapped) != 1 {
+		t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+	}
+	ve := unwrapped[0].(errors.ValidationError)
+	if ve.Code() != errors.CodeNotAllowed {
+		t.Errorf("Expected code %s, got: %s", errors.CodeNotAllowed, ve.Code())
+	}
+	if ve.Path() != "/errors/1/source" {
+		t.Errorf(`Expected path to be "/errors/1/source", got: "%s"`, ve.Path())
+	}
+}
+
+// Requirements:
+// - NewSource returns a source with the single field that is set.
+// - NewSource rejects a source with both pointer and parameter, or any other two fields, with CodeNotAllowed.
+func TestNewSource(t *testing.T) {
+	s, err := NewSource("/data/attributes/title", "", "")
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got: %s", err)
+	}
+	if *s != (Source{Pointer: "/data/attributes/title"}) {
+		t.Errorf("Expected a pointer source, got: %+v", s)
+	}
+
+	tests := [][3]string{
+		{"/data", "include", ""},
+		{"/data", "", "Accept"},
+		{"", "sort", "Accept"},
+	}
+	for _, tt := range tests {
+		s, err := NewSource(tt[0], tt[1], tt[2])
+		if err == nil {
+			t.Errorf("Expected an error for %v, got source: %+v", tt, s)
+			continue
+		}
+		if err.Code() != errors.CodeNotAllowed {
+			t.Errorf("Expected code %s, got: %s", errors.CodeNotAllowed, err.Code())
+		}
+	}
+}
+
+// Requirements:
+// - The resolver receives the error code and params.
+// - Empty resolver results keep the original title and detail.
+// - Source and status are preserved.
+func TestErrorsFromValidationErrorLocalized(t *testing.T) {
+	ctx := rulecontext.WithPathString(context.Background(), "title")
+	e1 := errors.Errorf(errors.CodeMin, ctx, "too short", "must be at least %d characters", 3)
+	e2 := errors.Errorf(errors.CodePattern, ctx, "bad pattern", "does not match")
+	joined := errors.Join(e1, e2)
+
+	var gotParams []any
+	list := ErrorsFromValidationErrorLocalized(joined, SourcePointer, func(code string, params []any) (string, string) {
+		if code != string(errors.CodeMin) {
+			return "", ""
+		}
+		gotParams = params
+		return "trop court", "doit contenir au moins 3 caractères"
+	})
+
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 errors, got: %d", len(list))
+	}
+	byCode := map[string]Error{}
+	for _, e := range list {
+		byCode[e.Code] = e
+	}
+	if e := byCode[string(errors.CodeMin)]; e.Title != "trop court" || e.Detail != "doit contenir au moins 3 caractères" {
+		t.Errorf("Expected localized text, got: %q / %q", e.Title, e.Detail)
+	}
+	if len(gotParams) != 1 || gotParams[0] != 3 {
+		t.Errorf("Expected params [3], got: %v", gotParams)
+	}
+	if e := byCode[string(errors.CodePattern)]; e.Title != "bad pattern" || e.Detail != "does not match" {
+		t.Errorf("Expected original text, got: %q / %q", e.Title, e.Detail)
+	}
+	for i, e := range list {
+		if e.Source == nil || e.Source.Pointer != "/title" {
+			t.Errorf("errors[%d]: expected pointer /title, got: %+v", i, e.Source)
+		}
+		if e.Status != "422" {
+			t.Errorf("errors[%d]: expected status 422, got: %q", i, e.Status)
+		}
+	}
+}
+
+// Requirements:
+// - Pointers that resolve within the document are kept.
+// - Pointers that do not resolve are dropped and the error is kept without a source.
+// - Escaped member names and array indexes are resolved.
+func TestErrorsFromValidationErrorVerified(t *testing.T) {
+	var doc any
+	if err := json.Unmarshal([]byte(`{"data":[{"type":"articles","attributes":{"a/b":"x"}}]}`), &doc); err != nil {
+		t.Fatalf("Expected valid JSON, got: %s", err)
+	}
+
+	base := rulecontext.WithPathString(context.Background(), "data")
+	base = rulecontext.WithPathString(base, "0")
+	good := rulecontext.WithPathString(rulecontext.WithPathString(base, "attributes"), "a/b")
+	bad := rulecontext.WithPathString(rulecontext.WithPathString(base, "attributes"), "missing")
+	joined := errors.Join(
+		errors.Errorf(errors.CodePattern, good, "bad pattern", "does not match"),
+		errors.Errorf(errors.CodeMin, bad, "too short", "too short"),
+	)
+
+	list := ErrorsFromValidationErrorVerified(joined, SourcePointer, doc)
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 errors, got: %d", len(list))
+	}
+	byCode := map[string]Error{}
+	for _, e := range list {
+		byCode[e.Code] = e
+	}
+	if e := byCode[string(errors.CodePattern)]; e.Source == nil || e.Source.Pointer != "/data/0/attributes/a~1b" {
+		t.Errorf("Expected pointer /data/0/attributes/a~1b, got: %+v", e.Source)
+	}
+	if e := byCode[string(errors.CodeMin)]; e.Source != nil {
+		t.Errorf("Expected the unresolved pointer to be dropped, got: %+v", e.Source)
+	}
+}
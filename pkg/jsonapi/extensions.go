@@ -1,8 +1,13 @@
 package jsonapi
 
 import (
+	"context"
 	"regexp"
+	"sort"
+	"strings"
 
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
 	"proto.zip/studio/validate/pkg/rules"
 )
 
@@ -11,3 +16,73 @@ var atMembersKeyRule = rules.String().WithRegexp(regexp.MustCompile(`^@`), "")
 // Extension member names must be prefixed with namespace followed by colon (e.g., "version:id")
 // Per spec, namespace must contain only a-z, A-Z, 0-9
 var extKeyRule = rules.String().WithRegexp(regexp.MustCompile(`^[a-zA-Z0-9]+:.+`), "")
+
+// isExtensionMemberName reports whether key is an extension member name according to extKeyRule, so that
+// decoding sorts members the same way validation does.
+func isExtensionMemberName(key string) bool {
+	return extKeyRule.Evaluate(context.Background(), key) == nil
+}
+
+// extensionNamespace returns the namespace of ext: its own Prefix when set, otherwise the Prefix of the
+// extension in known with the same URI, otherwise the namespace of the official extension.
+// It returns "" if no namespace is found.
+func extensionNamespace(ext Extension, known []Extension) string {
+	if ext.Prefix != "" {
+		return ext.Prefix
+	}
+	for _, k := range known {
+		if k.URI == ext.URI && k.Prefix != "" {
+			return k.Prefix
+		}
+	}
+	return officialExtensionPrefix(ext.URI)
+}
+
+// extensionNamespaces returns the set of namespaces of exts (see extensionNamespace).
+func extensionNamespaces(exts []Extension, known []Extension) map[string]bool {
+	namespaces := make(map[string]bool, len(exts))
+	for _, ext := range exts {
+		if prefix := extensionNamespace(ext, known); prefix != "" {
+			namespaces[prefix] = true
+		}
+	}
+	return namespaces
+}
+
+// evaluateExtensionMembers checks that every extension member uses a namespace negotiated in the context.
+// Errors are reported on the member itself and sorted by member name.
+func evaluateExtensionMembers(ctx context.Context, members map[string]any, known []Extension) errors.ValidationError {
+	if len(members) == 0 {
+		return nil
+	}
+	return evaluateMemberNamespaces(ctx, members, extensionNamespaces(ExtensionsFromContext(ctx), known), "extension not negotiated", "extension namespace %q was not negotiated")
+}
+
+// evaluateDeclaredExtensionMembers checks that every extension member uses the namespace of a declared extension.
+// Errors are reported on the member itself and sorted by member name.
+func evaluateDeclaredExtensionMembers(ctx context.Context, members map[string]any, declared []Extension) errors.ValidationError {
+	if len(members) == 0 {
+		return nil
+	}
+	return evaluateMemberNamespaces(ctx, members, extensionNamespaces(declared, nil), "extension not declared", "extension namespace %q is not declared")
+}
+
+// evaluateMemberNamespaces returns a CodeUnexpected error for each member whose namespace is not in namespaces.
+func evaluateMemberNamespaces(ctx context.Context, members map[string]any, namespaces map[string]bool, short, long string) errors.ValidationError {
+	keys := make([]string, 0, len(members))
+	for key := range members {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var allErrors []error
+	for _, key := range keys {
+		namespace, _, _ := strings.Cut(key, ":")
+		if namespaces[namespace] {
+			continue
+		}
+		memberCtx := rulecontext.WithPathString(ctx, key)
+		allErrors = append(allErrors, errors.Errorf(errors.CodeUnexpected, memberCtx, short, long, namespace))
+	}
+	return errors.Join(allErrors...)
+}
@@ -0,0 +1,14 @@
+package jsonapi
+
+// ApplyFieldsets sets datum.Fields from the sparse fieldset requested for the datum's type
+// (the fields[<type>] query parameter). Fields is set to nil, serializing all fields, when no fieldset applies.
+func ApplyFieldsets[T any](datum *Datum[T], q QueryData) {
+	datum.Fields = q.Fields["fields["+datum.Type+"]"]
+}
+
+// ApplyCollectionFieldsets applies ApplyFieldsets to every datum in data.
+func ApplyCollectionFieldsets[T any](data []Datum[T], q QueryData) {
+	for i := range data {
+		ApplyFieldsets(&data[i], q)
+	}
+}
@@ -0,0 +1,52 @@
+package jsonapi_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+)
+
+// Requirements:
+// - The fieldset for the datum's type is applied.
+// - Datums of other types serialize all fields.
+// - The collection variant applies fieldsets to every datum.
+func TestApplyFieldsets(t *testing.T) {
+	values, _ := url.ParseQuery("fields[articles]=title&include=author&sort=-created")
+	q := jsonapi.QueryDataFromValues(values)
+
+	if q.Include == nil || !q.Include.Contains("author") {
+		t.Errorf("Expected include to contain author, got %v", q.Include)
+	}
+	if len(q.Sort) != 1 || q.Sort[0].Field != "created" || !q.Sort[0].Descending {
+		t.Errorf("Expected sort [-created], got %v", q.Sort)
+	}
+
+	data := []jsonapi.Datum[map[string]any]{
+		{ID: "1", Type: "articles", Attributes: map[string]any{"title": "Hello", "body": "World"}},
+		{ID: "9", Type: "people", Attributes: map[string]any{"name": "Dan"}, Fields: jsonapi.NewFieldList()},
+	}
+	jsonapi.ApplyCollectionFieldsets(data, q)
+
+	if data[0].Fields == nil || !data[0].Fields.Contains("title") || data[0].Fields.Contains("body") {
+		t.Errorf("Expected articles fieldset to be applied, got %v", data[0].Fields)
+	}
+	if data[1].Fields != nil {
+		t.Errorf("Expected Fields to be nil when no fieldset applies, got %v", data[1].Fields)
+	}
+
+	actual, err := json.Marshal(data[0])
+	if err != nil {
+		t.Fatalf("Unexpected error during marshalling: %v", err)
+	}
+	if !jsonEqual(`{"id":"1","type":"articles","attributes":{"title":"Hello"}}`, string(actual)) {
+		t.Errorf("Unexpected JSON: %s", actual)
+	}
+
+	datum := jsonapi.Datum[map[string]any]{ID: "2", Type: "articles"}
+	jsonapi.ApplyFieldsets(&datum, jsonapi.QueryData{})
+	if datum.Fields != nil {
+		t.Errorf("Expected Fields to be nil without fieldsets, got %v", datum.Fields)
+	}
+}
@@ -1,5 +1,11 @@
 package jsonapi
 
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
 type Version string
 
 const (
@@ -23,3 +29,49 @@ type Header struct {
 	Profile []Profile      `json:"profile"`
 	Meta    map[string]any `json:"meta"`
 }
+
+// ExtensionByPrefix returns the extension in the header whose prefix (namespace) is prefix,
+// e.g. to find the extension that an extension member such as "atomic:operations" belongs to.
+func (h Header) ExtensionByPrefix(prefix string) (Extension, bool) {
+	for _, ext := range h.Ext {
+		if ext.Prefix != "" && ext.Prefix == prefix {
+			return ext, true
+		}
+	}
+	return Extension{}, false
+}
+
+// officialExtensionBase is the URI prefix of the extensions published by JSON:API. Their namespace is
+// the final path segment, e.g. "atomic" for https://jsonapi.org/ext/atomic.
+const officialExtensionBase = "https://jsonapi.org/ext/"
+
+var extensionNamespacePattern = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// officialExtensionPrefix returns the namespace of the official JSON:API extension with the given URI,
+// or "" if uri is not an official extension.
+func officialExtensionPrefix(uri string) string {
+	if name, ok := strings.CutPrefix(uri, officialExtensionBase); ok && extensionNamespacePattern.MatchString(name) {
+		return name
+	}
+	return ""
+}
+
+// JSONAPIObject is the top-level jsonapi member describing the server's implementation.
+// It lets servers advertise the spec version and the extensions and profiles they apply.
+type JSONAPIObject struct {
+	Version Version        `json:"version,omitempty"`
+	Ext     []string       `json:"ext,omitempty"`
+	Profile []string       `json:"profile,omitempty"`
+	Meta    map[string]any `json:"meta,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler for JSONAPIObject.
+// Empty slices are omitted and the version defaults to 1.1 when unset.
+func (o JSONAPIObject) MarshalJSON() ([]byte, error) {
+	type plain JSONAPIObject
+	out := plain(o)
+	if out.Version == "" {
+		out.Version = Version_1_1
+	}
+	return json.Marshal(out)
+}
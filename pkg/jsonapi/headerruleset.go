@@ -5,6 +5,7 @@ import (
 	"mime"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 
 	"proto.zip/studio/validate/pkg/errors"
@@ -29,6 +30,7 @@ type HeaderRuleSet struct {
 	extRuleSet      rules.RuleSet[any]
 	profileRuleSet  rules.RuleSet[any]
 	headerRules     map[string]rules.RuleSet[any]
+	allowedHeaders  map[string]bool
 }
 
 // Headers returns a new HeaderRuleSet that validates Content-Type and optionally ext/profile and custom headers.
@@ -49,6 +51,12 @@ func (h *HeaderRuleSet) clone() *HeaderRuleSet {
 	for k, v := range h.headerRules {
 		c.headerRules[k] = v
 	}
+	if h.allowedHeaders != nil {
+		c.allowedHeaders = make(map[string]bool, len(h.allowedHeaders))
+		for k, v := range h.allowedHeaders {
+			c.allowedHeaders[k] = v
+		}
+	}
 	return c
 }
 
@@ -86,6 +94,51 @@ func (h *HeaderRuleSet) WithHeader(name string, ruleSet rules.RuleSet[any]) *Hea
 	return c
 }
 
+// WithPrefer validates the return preference of the Prefer header (return=minimal or return=representation).
+// Use PreferReturnFromHeader to read the parsed preference.
+func (h *HeaderRuleSet) WithPrefer() *HeaderRuleSet {
+	return h.WithHeader("Prefer", PreferRuleSet.Any())
+}
+
+// standardRequestHeaders are the standard HTTP request headers accepted in strict mode without being allowlisted.
+var standardRequestHeaders = map[string]bool{
+	"Accept": true, "Accept-Charset": true, "Accept-Encoding": true, "Accept-Language": true,
+	"Authorization": true, "Cache-Control": true, "Connection": true, "Content-Encoding": true,
+	"Content-Length": true, "Content-Type": true, "Cookie": true, "Date": true, "Expect": true,
+	"Forwarded": true, "From": true, "Host": true, "If-Match": true, "If-Modified-Since": true,
+	"If-None-Match": true, "If-Range": true, "If-Unmodified-Since": true, "Max-Forwards": true,
+	"Origin": true, "Pragma": true, "Prefer": true, "Proxy-Authorization": true, "Range": true,
+	"Referer": true, "Te": true, "Trailer": true, "Transfer-Encoding": true, "Upgrade": true,
+	"User-Agent": true, "Via": true,
+}
+
+// WithAllowedHeaders enables strict mode: any request header that is not a standard HTTP header,
+// not registered with WithHeader, and not in names is rejected. Calling it again adds to the allowlist.
+func (h *HeaderRuleSet) WithAllowedHeaders(names ...string) *HeaderRuleSet {
+	c := h.clone()
+	if c.allowedHeaders == nil {
+		c.allowedHeaders = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		c.allowedHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	return c
+}
+
+// headerAllowed reports whether name may appear on a request in strict mode.
+func (h *HeaderRuleSet) headerAllowed(name string) bool {
+	canonical := http.CanonicalHeaderKey(name)
+	if standardRequestHeaders[canonical] || h.allowedHeaders[canonical] {
+		return true
+	}
+	for registered := range h.headerRules {
+		if http.CanonicalHeaderKey(registered) == canonical {
+			return true
+		}
+	}
+	return false
+}
+
 // getHeader returns the first value for name from headers. Name is case-insensitive under http.Header.
 func getHeader(headers http.Header, name string) string {
 	v := headers[name]
@@ -122,7 +175,8 @@ func headerToHTTP(in *Header) http.Header {
 
 // httpHeaderToHeader parses Content-Type and populates the JSON:API Header struct (Version, Ext, Profile).
 // Meta is not set from headers. Version defaults to Version_1_1 when Content-Type is present.
-func httpHeaderToHeader(headers http.Header) *Header {
+// Extensions get the prefix of the extension in known with the same URI, or of the official extension.
+func httpHeaderToHeader(headers http.Header, known []Extension) *Header {
 	out := &Header{Version: Version_1_1}
 	raw := getHeader(headers, "Content-Type")
 	if raw == "" {
@@ -134,7 +188,7 @@ func httpHeaderToHeader(headers http.Header) *Header {
 	}
 	if v := params[contentTypeParamExt]; v != "" {
 		for _, uri := range strings.Fields(v) {
-			out.Ext = append(out.Ext, Extension{URI: uri})
+			out.Ext = append(out.Ext, Extension{URI: uri, Prefix: extensionNamespace(Extension{URI: uri}, known)})
 		}
 	}
 	if v := params[contentTypeParamProfile]; v != "" {
@@ -146,6 +200,7 @@ func httpHeaderToHeader(headers http.Header) *Header {
 }
 
 // validateContentType checks Content-Type is application/vnd.api+json and only ext/profile params.
+// Media type and parameter failures use CodeUnsupportedMediaType so they are reported with status 415.
 func (h *HeaderRuleSet) validateContentType(ctx context.Context, headers http.Header) errors.ValidationError {
 	headerCtx := rulecontext.WithPathString(ctx, "Content-Type")
 	raw := getHeader(headers, "Content-Type")
@@ -157,14 +212,14 @@ func (h *HeaderRuleSet) validateContentType(ctx context.Context, headers http.He
 	}
 	mediaType, params, err := mime.ParseMediaType(raw)
 	if err != nil {
-		return errors.Errorf(errors.CodeEncoding, headerCtx, "invalid Content-Type", "Content-Type header is invalid: %v", err)
+		return errors.Errorf(CodeUnsupportedMediaType, headerCtx, "invalid Content-Type", "Content-Type header is invalid: %v", err)
 	}
 	if mediaType != MediaTypeJSONAPI {
-		return errors.Errorf(errors.CodePattern, headerCtx, "wrong media type", "Content-Type must be %s, got %q", MediaTypeJSONAPI, mediaType)
+		return errors.Errorf(CodeUnsupportedMediaType, headerCtx, "wrong media type", "Content-Type must be %s, got %q", MediaTypeJSONAPI, mediaType)
 	}
 	for name := range params {
 		if name != contentTypeParamExt && name != contentTypeParamProfile {
-			return errors.Errorf(errors.CodeUnexpected, headerCtx, "disallowed parameter", "Content-Type parameter %q is not allowed (only ext and profile)", name)
+			return errors.Errorf(CodeUnsupportedMediaType, headerCtx, "disallowed parameter", "Content-Type parameter %q is not allowed (only ext and profile)", name)
 		}
 	}
 
@@ -196,7 +251,13 @@ func (h *HeaderRuleSet) Evaluate(ctx context.Context, headers http.Header) error
 	if contentErr != nil {
 		errs = append(errs, errors.Unwrap(contentErr)...)
 	}
-	for name, ruleSet := range h.headerRules {
+	ruleNames := make([]string, 0, len(h.headerRules))
+	for name := range h.headerRules {
+		ruleNames = append(ruleNames, name)
+	}
+	sort.Strings(ruleNames)
+	for _, name := range ruleNames {
+		ruleSet := h.headerRules[name]
 		if ruleSet == nil {
 			continue
 		}
@@ -206,6 +267,20 @@ func (h *HeaderRuleSet) Evaluate(ctx context.Context, headers http.Header) error
 			errs = append(errs, errors.Unwrap(err)...)
 		}
 	}
+	if h.allowedHeaders != nil {
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if h.headerAllowed(name) {
+				continue
+			}
+			headerCtx := rulecontext.WithPathString(ctx, name)
+			errs = append(errs, errors.Errorf(errors.CodeUnexpected, headerCtx, "unexpected header", "Header %q is not allowed", name))
+		}
+	}
 	if len(errs) == 0 {
 		return nil
 	}
@@ -214,7 +214,7 @@ func TestHeaderRuleSet_Apply_OutputJsonAPIHeader(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error: %v", err)
 	}
-	out := *httpHeaderToHeader(headers)
+	out := *httpHeaderToHeader(headers, nil)
 	if out.Version != Version_1_1 {
 		t.Errorf("expected Version %q, got %q", Version_1_1, out.Version)
 	}
@@ -239,7 +239,7 @@ func TestHeaderRuleSet_Apply_HeaderRoundtrip(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error: %v", err)
 	}
-	out := *httpHeaderToHeader(headers)
+	out := *httpHeaderToHeader(headers, nil)
 	if out.Version != Version_1_1 {
 		t.Errorf("expected Version %q, got %q", Version_1_1, out.Version)
 	}
@@ -251,6 +251,42 @@ func TestHeaderRuleSet_Apply_HeaderRoundtrip(t *testing.T) {
 	}
 }
 
+// Requirements:
+// - Each space-separated ext URI becomes an Extension in order.
+// - Official extensions and extensions in the known list get their prefix; unknown ones have none.
+// - ExtensionByPrefix finds an extension by its namespace.
+func TestHttpHeaderToHeader_ExtensionPrefixes(t *testing.T) {
+	known := []Extension{{URI: "https://example.com/ext/audit", Prefix: "audit"}}
+
+	h := http.Header{}
+	h.Set("Content-Type", `application/vnd.api+json; ext="https://jsonapi.org/ext/atomic https://example.com/ext/audit https://example.com/ext/other"`)
+	out := httpHeaderToHeader(h, known)
+
+	want := []Extension{
+		{URI: "https://jsonapi.org/ext/atomic", Prefix: "atomic"},
+		{URI: "https://example.com/ext/audit", Prefix: "audit"},
+		{URI: "https://example.com/ext/other"},
+	}
+	if len(out.Ext) != len(want) {
+		t.Fatalf("expected %d extensions, got %+v", len(want), out.Ext)
+	}
+	for i := range want {
+		if out.Ext[i] != want[i] {
+			t.Errorf("ext[%d]: expected %+v, got %+v", i, want[i], out.Ext[i])
+		}
+	}
+
+	if ext, ok := out.ExtensionByPrefix("atomic"); !ok || ext.URI != "https://jsonapi.org/ext/atomic" {
+		t.Errorf("expected the atomic extension, got %+v", ext)
+	}
+	if _, ok := out.ExtensionByPrefix("other"); ok {
+		t.Error("expected no extension for an unknown prefix")
+	}
+	if _, ok := out.ExtensionByPrefix(""); ok {
+		t.Error("expected no extension for an empty prefix")
+	}
+}
+
 func TestHeaderRuleSet_WithContentRequired(t *testing.T) {
 	rs := Headers().WithContentRequired(false)
 	ctx := context.Background()
@@ -297,3 +333,117 @@ func TestHeaderRuleSet_RequiredStringReplacesAny(t *testing.T) {
 		t.Error("Any() should not be nil")
 	}
 }
+
+func TestHeaderRuleSet_WithAllowedHeaders(t *testing.T) {
+	ctx := context.Background()
+	h := http.Header{}
+	h.Set("Content-Type", MediaTypeJSONAPI)
+	h.Set("Accept", MediaTypeJSONAPI)
+	h.Set("X-Custom", "value")
+
+	// Default mode does not restrict headers.
+	if _, err := Headers().Apply(ctx, h); err != nil {
+		t.Fatalf("expected no error without allowlist: %v", err)
+	}
+
+	rs := Headers().WithAllowedHeaders("X-Request-Id")
+	_, err := rs.Apply(ctx, h)
+	if err == nil {
+		t.Fatal("expected error for unexpected X-Custom header in strict mode")
+	}
+	list := ErrorsFromValidationError(err, SourceHeader)
+	if len(list) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(list))
+	}
+	if list[0].Source == nil || list[0].Source.Header != "X-Custom" {
+		t.Errorf("expected source.header = X-Custom, got %v", list[0].Source)
+	}
+
+	// Allowlisted and registered headers are accepted.
+	if _, err := rs.WithAllowedHeaders("x-custom").Apply(ctx, h); err != nil {
+		t.Errorf("expected allowlisted header to pass: %v", err)
+	}
+	if _, err := rs.WithHeader("X-Custom", rules.String().Any()).Apply(ctx, h); err != nil {
+		t.Errorf("expected registered header to pass: %v", err)
+	}
+}
+
+func TestHeaderRuleSet_Status(t *testing.T) {
+	ctx := context.Background()
+	rs := Headers().WithHeader("X-Request-Id", rules.String().WithMinLen(1).Any())
+
+	tests := []struct {
+		name        string
+		contentType string
+		requestID   string
+		status      int
+	}{
+		{"wrong media type", "application/json", "abc", 415},
+		{"disallowed charset", MediaTypeJSONAPI + "; charset=utf-8", "abc", 415},
+		{"missing custom header", MediaTypeJSONAPI, "", 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("Content-Type", tt.contentType)
+			h.Set("X-Request-Id", tt.requestID)
+			_, err := rs.Apply(ctx, h)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			list := ErrorsFromValidationError(err, SourceHeader)
+			if got := ErrorsStatus(list); got != tt.status {
+				t.Errorf("expected status %d, got %d (%+v)", tt.status, got, list)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - Valid return preferences pass and are parsed, alongside other preferences.
+// - An unknown return value errors with source.header Prefer.
+// - A missing Prefer header passes and is unspecified.
+func TestHeaderRuleSet_WithPrefer(t *testing.T) {
+	rs := Headers().WithPrefer()
+	ctx := context.Background()
+
+	valid := map[string]ReturnPreference{
+		"return=minimal":                       ReturnMinimal,
+		"respond-async, return=representation": ReturnRepresentation,
+		`return="minimal"; foo=bar, wait=10`:   ReturnMinimal,
+		"wait=10":                              ReturnUnspecified,
+	}
+	for value, expected := range valid {
+		h := http.Header{}
+		h.Set("Content-Type", MediaTypeJSONAPI)
+		h.Set("Prefer", value)
+		if err := rs.Evaluate(ctx, h); err != nil {
+			t.Errorf("Expected Prefer %q to pass, got: %s", value, err)
+		}
+		if preference := PreferReturnFromHeader(h); preference != expected {
+			t.Errorf("Expected Prefer %q to parse as %q, got %q", value, expected, preference)
+		}
+	}
+
+	h := http.Header{}
+	h.Set("Content-Type", MediaTypeJSONAPI)
+	if err := rs.Evaluate(ctx, h); err != nil {
+		t.Errorf("Expected missing Prefer to pass, got: %s", err)
+	}
+	if preference := PreferReturnFromHeader(h); preference != ReturnUnspecified {
+		t.Errorf("Expected missing Prefer to be unspecified, got %q", preference)
+	}
+
+	h.Set("Prefer", "return=everything")
+	err := rs.Evaluate(ctx, h)
+	if err == nil {
+		t.Fatal("Expected invalid return preference to be rejected")
+	}
+	list := ErrorsFromValidationError(err, SourceHeader)
+	if len(list) != 1 || list[0].Source == nil || list[0].Source.Header != "Prefer" {
+		t.Errorf("Expected a single error with source.header Prefer, got %+v", list)
+	}
+	if PreferReturnFromHeader(h) != ReturnUnspecified {
+		t.Errorf("Expected unknown return value to be unspecified")
+	}
+}
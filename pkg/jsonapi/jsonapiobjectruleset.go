@@ -0,0 +1,130 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// defaultSupportedVersions are the JSON:API versions accepted in the jsonapi member by default.
+var defaultSupportedVersions = []Version{Version_1_0, Version_1_1}
+
+// newJSONAPIObjectRuleSet returns a rule set for the top-level jsonapi member.
+// The version must be one of versions, ext and profile must be arrays of absolute URIs,
+// and every URI in requiredExtensions must appear in ext.
+func newJSONAPIObjectRuleSet(versions []Version, requiredExtensions []string) rules.RuleSet[*JSONAPIObject] {
+	return rules.Interface[*JSONAPIObject]().WithCast(func(ctx context.Context, value any) (*JSONAPIObject, errors.ValidationError) {
+		mapValue, ok := value.(map[string]any)
+		if !ok {
+			return nil, errors.Errorf(errors.CodeType, ctx, "object", reflect.ValueOf(value).Kind().String())
+		}
+
+		var allErrors []error
+		if version, ok := mapValue["version"]; ok {
+			versionCtx := rulecontext.WithPathString(ctx, "version")
+			if !supportedVersion(version, versions) {
+				allErrors = append(allErrors, errors.Errorf(errors.CodeUnexpected, versionCtx, "unsupported version", "jsonapi version %v is not supported (supported: %v)", version, versions))
+			}
+		}
+		for _, member := range []string{"ext", "profile"} {
+			if memberValue, ok := mapValue[member]; ok {
+				if errs := evaluateURIArray(rulecontext.WithPathString(ctx, member), memberValue); errs != nil {
+					allErrors = append(allErrors, errors.Unwrap(errs)...)
+				}
+			}
+		}
+		if meta, ok := mapValue["meta"]; ok {
+			if _, isMap := meta.(map[string]any); !isMap {
+				metaCtx := rulecontext.WithPathString(ctx, "meta")
+				allErrors = append(allErrors, errors.Errorf(errors.CodeType, metaCtx, "object", reflect.ValueOf(meta).Kind().String()))
+			}
+		}
+		if errs := errors.Join(allErrors...); errs != nil {
+			return nil, errs
+		}
+
+		jsonBytes, err := json.Marshal(mapValue)
+		if err != nil {
+			return nil, errors.Errorf(errors.CodeEncoding, ctx, "jsonapi marshal failed", "Failed to marshal jsonapi object: %v", err)
+		}
+
+		var out JSONAPIObject
+		if err := json.Unmarshal(jsonBytes, &out); err != nil {
+			return nil, errors.Errorf(errors.CodeEncoding, ctx, "Invalid jsonapi object", "Invalid jsonapi object: %v", err)
+		}
+
+		if errs := evaluateRequiredExtensions(ctx, &out, requiredExtensions); errs != nil {
+			return nil, errs
+		}
+		return &out, nil
+	})
+}
+
+// supportedVersion reports whether value is a string matching one of versions.
+func supportedVersion(value any, versions []Version) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	for _, version := range versions {
+		if Version(str) == version {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateURIArray checks that value is an array of absolute URI strings.
+func evaluateURIArray(ctx context.Context, value any) errors.ValidationError {
+	items, ok := value.([]any)
+	if !ok {
+		return errors.Errorf(errors.CodeType, ctx, "array", reflect.ValueOf(value).Kind().String())
+	}
+
+	var allErrors []error
+	for i, item := range items {
+		itemCtx := rulecontext.WithPathString(ctx, strconv.Itoa(i))
+		str, ok := item.(string)
+		if !ok {
+			allErrors = append(allErrors, errors.Errorf(errors.CodeType, itemCtx, "string", reflect.ValueOf(item).Kind().String()))
+			continue
+		}
+		if uri, err := url.Parse(str); err != nil || !uri.IsAbs() {
+			allErrors = append(allErrors, errors.Errorf(errors.CodePattern, itemCtx, "invalid URI", "%q is not an absolute URI", str))
+		}
+	}
+	return errors.Join(allErrors...)
+}
+
+// evaluateRequiredExtensions checks that every required extension URI is listed in the jsonapi object.
+// A nil object is treated as listing no extensions.
+func evaluateRequiredExtensions(ctx context.Context, object *JSONAPIObject, requiredExtensions []string) errors.ValidationError {
+	if len(requiredExtensions) == 0 {
+		return nil
+	}
+
+	present := make(map[string]bool)
+	if object != nil {
+		for _, uri := range object.Ext {
+			present[uri] = true
+		}
+	}
+
+	var allErrors []error
+	extCtx := rulecontext.WithPathString(ctx, "ext")
+	for _, uri := range requiredExtensions {
+		if !present[uri] {
+			allErrors = append(allErrors, errors.Errorf(errors.CodeRequired, extCtx, "extension required", "extension %q is required", uri))
+		}
+	}
+	return errors.Join(allErrors...)
+}
+
+// JSONAPIObjectRuleSet validates the top-level jsonapi member with the default supported versions (1.0 and 1.1).
+var JSONAPIObjectRuleSet rules.RuleSet[*JSONAPIObject] = newJSONAPIObjectRuleSet(defaultSupportedVersions, nil)
@@ -0,0 +1,153 @@
+package jsonapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// jsonPointerEscaper escapes member names for use in a JSON Pointer (RFC 6901).
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// jsonPointerUnescaper reverses jsonPointerEscaper.
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// jsonPointerResolves reports whether pointer references a value that exists in doc, a document decoded
+// with encoding/json into an any. The empty pointer references the whole document.
+func jsonPointerResolves(doc any, pointer string) bool {
+	if pointer == "" {
+		return true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return false
+	}
+	value := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = jsonPointerUnescaper.Replace(token)
+		switch v := value.(type) {
+		case map[string]any:
+			member, ok := v[token]
+			if !ok {
+				return false
+			}
+			value = member
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(v) || strconv.Itoa(index) != token {
+				return false
+			}
+			value = v[index]
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// jsonSyntaxFrame tracks the position inside an object or array while tokenizing.
+type jsonSyntaxFrame struct {
+	object    bool
+	expectKey bool
+	key       string
+	index     int
+}
+
+// jsonSyntaxPointer returns the JSON Pointer to the innermost member or element being decoded.
+func jsonSyntaxPointer(stack []jsonSyntaxFrame) string {
+	var b strings.Builder
+	for _, frame := range stack {
+		switch {
+		case frame.object && !frame.expectKey:
+			b.WriteString("/" + jsonPointerEscaper.Replace(frame.key))
+		case !frame.object && frame.index >= 0:
+			b.WriteString("/" + strconv.Itoa(frame.index))
+		}
+	}
+	return b.String()
+}
+
+// jsonSyntaxLocation decodes data token by token and returns the JSON Pointer of the closest member
+// and the byte offset at which decoding failed. err is nil if data is a valid JSON value.
+func jsonSyntaxLocation(data []byte) (pointer string, offset int64, err error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var stack []jsonSyntaxFrame
+
+	// valueStarted and valueEnded update the parent frame around each value.
+	valueStarted := func() {
+		if len(stack) > 0 && !stack[len(stack)-1].object {
+			stack[len(stack)-1].index++
+		}
+	}
+	valueEnded := func() {
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].expectKey = true
+		}
+	}
+
+	for {
+		tok, tokErr := dec.Token()
+		if tokErr == io.EOF {
+			if len(stack) == 0 && dec.InputOffset() > 0 {
+				return "", 0, nil
+			}
+			tokErr = io.ErrUnexpectedEOF
+		}
+		if tokErr != nil {
+			offset = dec.InputOffset()
+			var syntaxErr *json.SyntaxError
+			if errors.As(tokErr, &syntaxErr) {
+				offset = syntaxErr.Offset
+			}
+			return jsonSyntaxPointer(stack), offset, tokErr
+		}
+
+		if delim, ok := tok.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				valueStarted()
+				stack = append(stack, jsonSyntaxFrame{object: delim == '{', expectKey: delim == '{', index: -1})
+			case '}', ']':
+				stack = stack[:len(stack)-1]
+				valueEnded()
+			}
+			continue
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].object && stack[len(stack)-1].expectKey {
+			stack[len(stack)-1].key, _ = tok.(string)
+			stack[len(stack)-1].expectKey = false
+			continue
+		}
+		valueStarted()
+		valueEnded()
+	}
+}
+
+// MalformedJSONError returns a JSON:API error with status 400 describing why data is not valid JSON.
+// When possible the detail includes the byte offset and the source pointer references the closest
+// member that was being decoded.
+func MalformedJSONError(data []byte) *Error {
+	e := &Error{
+		Status: "400",
+		Code:   string(CodeMalformedJSON),
+		Title:  "Invalid JSON encoding",
+		Detail: "Body must be Json encoded",
+	}
+
+	pointer, offset, err := jsonSyntaxLocation(data)
+	if err == nil {
+		return e
+	}
+
+	location := "document root"
+	if pointer != "" {
+		location = pointer
+		e.Source = &Source{Pointer: pointer}
+	}
+	e.Detail = fmt.Sprintf("Body must be Json encoded: %s at %s (offset %d)", err.Error(), location, offset)
+	return e
+}
@@ -0,0 +1,70 @@
+package jsonapi_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+)
+
+// Requirements:
+// - Malformed JSON produces a 400 error.
+// - The detail includes the byte offset and the closest member.
+// - The source pointer references the closest member when there is one.
+func TestMalformedJSONError(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		pointer string
+	}{
+		{"not json", `not json at all`, ""},
+		{"empty", ``, ""},
+		{"bad attribute value", `{"data": {"type": "articles", "attributes": {"title": tru}}}`, "/data/attributes/title"},
+		{"trailing comma", `{"data": {"type": "articles", "attributes": {"title": "x",}}}`, "/data/attributes"},
+		{"array element", `{"data": [{"type": "articles"}, {"type": }]}`, "/data/1/type"},
+		{"truncated", `{"data": {"type": "articles"`, "/data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := jsonapi.MalformedJSONError([]byte(tt.body))
+			if e.Status != "400" {
+				t.Errorf("Expected status 400, got %q", e.Status)
+			}
+			if !strings.Contains(e.Detail, "offset") {
+				t.Errorf("Expected detail to include the offset, got %q", e.Detail)
+			}
+			if tt.pointer == "" {
+				if e.Source != nil {
+					t.Errorf("Expected no source, got %+v", e.Source)
+				}
+				return
+			}
+			if e.Source == nil || e.Source.Pointer != tt.pointer {
+				t.Errorf("Expected pointer %q, got %+v", tt.pointer, e.Source)
+			}
+			if !strings.Contains(e.Detail, tt.pointer) {
+				t.Errorf("Expected detail to include %q, got %q", tt.pointer, e.Detail)
+			}
+		})
+	}
+}
+
+func TestSingleRuleSet_MalformedJSON(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", jsonapi.Attributes().WithUnknown())
+	_, errs := ruleSet.Apply(context.Background(), `{"data": {"type": "articles", "attributes": {"title": tru}}}`)
+	if errs == nil {
+		t.Fatal("Expected errors for malformed JSON")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Status != "400" {
+		t.Errorf("Expected status 400, got %q", list[0].Status)
+	}
+	if list[0].Source == nil || list[0].Source.Pointer != "/data/attributes/title" {
+		t.Errorf("Expected pointer /data/attributes/title, got %+v", list[0].Source)
+	}
+}
@@ -0,0 +1,167 @@
+package jsonapi
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
+)
+
+// linkageMode selects how the included resources of a compound document are checked.
+type linkageMode int
+
+const (
+	// linkageNone performs no linkage checks.
+	linkageNone linkageMode = iota
+	// linkageFull requires every included resource to be reachable from primary data.
+	linkageFull
+	// linkageDeep also requires every relationship of an included resource to resolve within the document.
+	linkageDeep
+)
+
+// linkageReference is a resource identifier found in relationship data, with the context of its pointer.
+type linkageReference struct {
+	key string
+	ctx context.Context
+}
+
+// resourceKey returns a key identifying a resource object or identifier by type and id (or lid).
+// It returns false if the object has no type or no identity.
+func resourceKey(obj map[string]any) (string, bool) {
+	typeName, _ := obj["type"].(string)
+	if typeName == "" {
+		return "", false
+	}
+	if id, _ := obj["id"].(string); id != "" {
+		return typeName + "\x00id\x00" + id, true
+	}
+	if lid, _ := obj["lid"].(string); lid != "" {
+		return typeName + "\x00lid\x00" + lid, true
+	}
+	return "", false
+}
+
+// resourceReferences returns the resource identifiers in the relationships of a resource object,
+// in relationship name order, with contexts pointing at each identifier.
+func resourceReferences(ctx context.Context, obj map[string]any) []linkageReference {
+	relationships, ok := obj["relationships"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(relationships))
+	for name := range relationships {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var refs []linkageReference
+	relationshipsCtx := rulecontext.WithPathString(ctx, "relationships")
+	for _, name := range names {
+		rel, ok := relationships[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		dataCtx := rulecontext.WithPathString(rulecontext.WithPathString(relationshipsCtx, name), "data")
+		switch data := rel["data"].(type) {
+		case map[string]any:
+			if key, ok := resourceKey(data); ok {
+				refs = append(refs, linkageReference{key: key, ctx: dataCtx})
+			}
+		case []any:
+			for i, item := range data {
+				identifier, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+				if key, ok := resourceKey(identifier); ok {
+					refs = append(refs, linkageReference{key: key, ctx: rulecontext.WithPathString(dataCtx, strconv.Itoa(i))})
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// evaluateLinkage checks the included resources of a decoded document according to mode.
+// With linkageFull, an included resource that cannot be reached from primary data, directly or through
+// other included resources, errors at /included/N. With linkageDeep, a relationship of an included resource
+// that references a resource which is neither primary data nor included errors at its identifier.
+func evaluateLinkage(ctx context.Context, decodedInput any, mode linkageMode) errors.ValidationError {
+	inputMap, ok := decodedInput.(map[string]any)
+	if mode == linkageNone || !ok {
+		return nil
+	}
+	included, ok := inputMap["included"].([]any)
+	if !ok || len(included) == 0 {
+		return nil
+	}
+
+	var primary []map[string]any
+	switch data := inputMap["data"].(type) {
+	case map[string]any:
+		primary = append(primary, data)
+	case []any:
+		for _, item := range data {
+			if obj, ok := item.(map[string]any); ok {
+				primary = append(primary, obj)
+			}
+		}
+	}
+
+	includedCtx := rulecontext.WithPathString(ctx, "included")
+	known := make(map[string]bool, len(primary)+len(included))
+	for _, obj := range primary {
+		if key, ok := resourceKey(obj); ok {
+			known[key] = true
+		}
+	}
+	includedIndex := make(map[string]int, len(included))
+	for i, item := range included {
+		if obj, ok := item.(map[string]any); ok {
+			if key, ok := resourceKey(obj); ok {
+				includedIndex[key] = i
+				known[key] = true
+			}
+		}
+	}
+
+	// Walk relationships from primary data through included resources.
+	reached := make(map[int]bool, len(included))
+	var queue []map[string]any
+	queue = append(queue, primary...)
+	for len(queue) > 0 {
+		obj := queue[0]
+		queue = queue[1:]
+		for _, ref := range resourceReferences(ctx, obj) {
+			i, ok := includedIndex[ref.key]
+			if !ok || reached[i] {
+				continue
+			}
+			reached[i] = true
+			queue = append(queue, included[i].(map[string]any))
+		}
+	}
+
+	var allErrors []error
+	for i, item := range included {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		itemCtx := rulecontext.WithPathString(includedCtx, strconv.Itoa(i))
+		if !reached[i] {
+			allErrors = append(allErrors, errors.Errorf(errors.CodeUnexpected, itemCtx, "unlinked included resource", "Included resource is not linked from primary data"))
+		}
+		if mode != linkageDeep {
+			continue
+		}
+		for _, ref := range resourceReferences(itemCtx, obj) {
+			if !known[ref.key] {
+				allErrors = append(allErrors, errors.Errorf(errors.CodeRequired, ref.ctx, "unresolved reference", "Referenced resource is not included in the document"))
+			}
+		}
+	}
+	return errors.Join(allErrors...)
+}
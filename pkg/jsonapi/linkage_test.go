@@ -0,0 +1,90 @@
+package jsonapi_test
+
+import (
+	"context"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// twoLevelCompound links an article to its author (included) and the author to an employer (included).
+// The employer references a parent company that is not included.
+const twoLevelCompound = `{
+	"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"author": {"data": {"type": "people", "id": "9"}}}},
+	"included": [
+		{"type": "people", "id": "9", "attributes": {}, "relationships": {"employer": {"data": {"type": "companies", "id": "3"}}}},
+		{"type": "companies", "id": "3", "attributes": {}, "relationships": {"parent": {"data": {"type": "companies", "id": "99"}}}}
+	]
+}`
+
+// Requirements:
+// - Full linkage accepts resources reached through other included resources.
+// - Full linkage rejects an included resource nothing links to, at /included/N.
+func TestSingleRuleSet_WithFullLinkage(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithUnknownRelationships().
+		WithFullLinkage()
+	ctx := context.Background()
+
+	if _, errs := ruleSet.Apply(ctx, twoLevelCompound); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	unlinked := `{
+		"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"author": {"data": {"type": "people", "id": "9"}}}},
+		"included": [{"type": "people", "id": "9", "attributes": {}}, {"type": "comments", "id": "5", "attributes": {}}]
+	}`
+	_, errs := ruleSet.Apply(ctx, unlinked)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Code != string(errors.CodeUnexpected) {
+		t.Errorf("Expected code %s, got %s", errors.CodeUnexpected, list[0].Code)
+	}
+	if list[0].Source == nil || list[0].Source.Pointer != "/included/1" {
+		t.Errorf("Expected pointer /included/1, got %+v", list[0].Source)
+	}
+}
+
+// Requirements:
+// - Deep linkage follows relationships across included resources.
+// - A dangling reference from an included resource errors at its identifier.
+// - References to primary data resolve.
+func TestSingleRuleSet_WithDeepLinkage(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithUnknownRelationships().
+		WithDeepLinkage()
+	ctx := context.Background()
+
+	_, errs := ruleSet.Apply(ctx, twoLevelCompound)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Code != string(errors.CodeRequired) {
+		t.Errorf("Expected code %s, got %s", errors.CodeRequired, list[0].Code)
+	}
+	if want := "/included/1/relationships/parent/data"; list[0].Source == nil || list[0].Source.Pointer != want {
+		t.Errorf("Expected pointer %s, got %+v", want, list[0].Source)
+	}
+
+	resolved := `{
+		"data": {"type": "articles", "id": "1", "attributes": {}, "relationships": {"author": {"data": {"type": "people", "id": "9"}}}},
+		"included": [
+			{"type": "people", "id": "9", "attributes": {}, "relationships": {"articles": {"data": [{"type": "articles", "id": "1"}]}, "employer": {"data": {"type": "companies", "id": "3"}}}},
+			{"type": "companies", "id": "3", "attributes": {}}
+		]
+	}`
+	if _, errs := ruleSet.Apply(ctx, resolved); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+}
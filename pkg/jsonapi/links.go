@@ -3,6 +3,9 @@ package jsonapi
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 )
 
 type Link interface {
@@ -63,6 +66,12 @@ func (links *Links) UnmarshalJSON(data []byte) error {
 
 	// Iterate over the temporary map and unmarshal each link
 	for key, rawValue := range tempMap {
+		// A null link is kept as a NilLink so it marshals back to null
+		if string(rawValue) == "null" {
+			(*links)[key] = NilLink{}
+			continue
+		}
+
 		// First, try to unmarshal as a StringLink
 		var strLink StringLink
 		if err := json.Unmarshal(rawValue, &strLink); err == nil {
@@ -83,3 +92,158 @@ func (links *Links) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// Href returns the URL of the named link, or an empty string if the link is not present.
+func (links Links) Href(name string) string {
+	link, ok := links[name]
+	if !ok || link == nil {
+		return ""
+	}
+	return link.Href()
+}
+
+// Self returns the URL of the "self" link, or an empty string if it is not present.
+func (links Links) Self() string {
+	return links.Href("self")
+}
+
+// Related returns the URL of the "related" link, or an empty string if it is not present.
+func (links Links) Related() string {
+	return links.Href("related")
+}
+
+// Set sets the named link to a string link, allocating the map if necessary.
+func (links *Links) Set(name, href string) {
+	if *links == nil {
+		*links = make(Links)
+	}
+	(*links)[name] = StringLink(href)
+}
+
+// SetObject sets the named link to a link object, allocating the map if necessary.
+// A nil link object is stored as a NilLink so the member serializes as null.
+func (links *Links) SetObject(name string, l *FullLink) {
+	if *links == nil {
+		*links = make(Links)
+	}
+	if l == nil {
+		(*links)[name] = NilLink{}
+		return
+	}
+	(*links)[name] = l
+}
+
+// linkOptions holds the settings applied by LinkOption values.
+type linkOptions struct {
+	query url.Values
+	meta  map[string]any
+}
+
+// LinkOption configures a link built by RelatedLink.
+type LinkOption func(*linkOptions)
+
+// WithQuery adds query parameters (e.g. include or page[size]) to the link URL.
+// Parameters already present in the base URL are kept.
+func WithQuery(query url.Values) LinkOption {
+	return func(o *linkOptions) {
+		if o.query == nil {
+			o.query = make(url.Values)
+		}
+		for key, values := range query {
+			o.query[key] = append(o.query[key], values...)
+		}
+	}
+}
+
+// WithMeta sets the meta member of the link, which makes RelatedLink return a link object.
+func WithMeta(meta map[string]any) LinkOption {
+	return func(o *linkOptions) {
+		o.meta = meta
+	}
+}
+
+// RelatedLink builds a link to base with the given options applied.
+// It returns a StringLink, or a *FullLink when meta is set.
+func RelatedLink(base string, opts ...LinkOption) Link {
+	var o linkOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	href := base
+	if len(o.query) > 0 {
+		if u, err := url.Parse(base); err == nil {
+			query := u.Query()
+			for key, values := range o.query {
+				for _, value := range values {
+					query.Add(key, value)
+				}
+			}
+			u.RawQuery = query.Encode()
+			href = u.String()
+		}
+	}
+
+	if len(o.meta) > 0 {
+		return &FullLink{HrefValue: href, Meta: o.meta}
+	}
+	return StringLink(href)
+}
+
+// SelfLinkWithQuery returns base+path with the query string rebuilt from q, so pagination and self
+// links keep the client's include, fields, sort, filter, and page parameters.
+func SelfLinkWithQuery(base, path string, q QueryData) string {
+	href := base + path
+	if query := q.Values().Encode(); query != "" {
+		href += "?" + query
+	}
+	return href
+}
+
+// SelfLinkFromRequest returns a Links map with a "self" link to the URL of r, including its query string
+// so collection self links keep the client's include, fields, sort, filter, and page parameters.
+// Forwarded headers are ignored; use SelfLinkFromRequestTrusted behind a proxy.
+func SelfLinkFromRequest(r *http.Request) Links {
+	return selfLinkFromRequest(r, false)
+}
+
+// SelfLinkFromRequestTrusted is like SelfLinkFromRequest but takes the scheme and host from the
+// X-Forwarded-Proto and X-Forwarded-Host headers when they are present. A forwarded scheme other than
+// http or https is ignored. Only use it behind a proxy that sets or strips these headers.
+func SelfLinkFromRequestTrusted(r *http.Request) Links {
+	return selfLinkFromRequest(r, true)
+}
+
+// selfLinkFromRequest builds the self link of r, reading forwarded headers only if trustForwarded is set.
+func selfLinkFromRequest(r *http.Request, trustForwarded bool) Links {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := r.Host
+
+	if trustForwarded {
+		if proto := strings.ToLower(forwardedHeader(r, "X-Forwarded-Proto")); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if forwardedHost := forwardedHeader(r, "X-Forwarded-Host"); forwardedHost != "" {
+			host = forwardedHost
+		}
+	}
+
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     host,
+		Path:     r.URL.Path,
+		RawPath:  r.URL.RawPath,
+		RawQuery: r.URL.RawQuery,
+	}
+	return Links{"self": StringLink(u.String())}
+}
+
+// forwardedHeader returns the first value of a forwarded header, which proxies may append to as a
+// comma-separated list.
+func forwardedHeader(r *http.Request, name string) string {
+	value, _, _ := strings.Cut(r.Header.Get(name), ",")
+	return strings.TrimSpace(value)
+}
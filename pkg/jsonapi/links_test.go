@@ -1,10 +1,15 @@
 package jsonapi_test
 
 import (
+	"context"
 	"encoding/json"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
 )
 
 func TestFullLink_Href(t *testing.T) {
@@ -193,3 +198,173 @@ func TestLinks_UnmarshalJSON_EmptyMap(t *testing.T) {
 		t.Errorf("Expected empty links map, got %d links", len(links))
 	}
 }
+
+func TestLinks_Accessors(t *testing.T) {
+	var links jsonapi.Links
+
+	if links.Self() != "" {
+		t.Errorf("Expected empty self link on nil Links, got %q", links.Self())
+	}
+
+	links.Set("self", "https://example.com/articles/1")
+	links.SetObject("related", &jsonapi.FullLink{HrefValue: "https://example.com/articles/1/author"})
+	links.SetObject("describedby", nil)
+
+	if links.Self() != "https://example.com/articles/1" {
+		t.Errorf("Expected self link, got %q", links.Self())
+	}
+	if links.Related() != "https://example.com/articles/1/author" {
+		t.Errorf("Expected related link, got %q", links.Related())
+	}
+	if _, ok := links["self"].(jsonapi.StringLink); !ok {
+		t.Errorf("Expected 'self' link to be StringLink")
+	}
+	if _, ok := links["describedby"].(jsonapi.NilLink); !ok {
+		t.Errorf("Expected 'describedby' link to be NilLink")
+	}
+
+	encoded, err := json.Marshal(links)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling Links: %v", err)
+	}
+	expected := `{"describedby":null,"related":{"href":"https://example.com/articles/1/author"},"self":"https://example.com/articles/1"}`
+	if string(encoded) != expected {
+		t.Errorf("Expected %s, got %s", expected, encoded)
+	}
+}
+
+// Requirements:
+// - Links without next or prev pass.
+// - A complete set of pagination links passes.
+// - next without first errors at /links.
+func TestPaginationLinksRule(t *testing.T) {
+	ruleSet := jsonapi.LinksRuleSet.WithRule(jsonapi.PaginationLinksRule)
+	ctx := rulecontext.WithPathString(context.Background(), "links")
+
+	if _, errs := ruleSet.Apply(ctx, map[string]any{"self": "/articles"}); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	complete := map[string]any{
+		"self":  "/articles?page[number]=2",
+		"first": "/articles?page[number]=1",
+		"prev":  "/articles?page[number]=1",
+		"next":  "/articles?page[number]=3",
+		"last":  "/articles?page[number]=9",
+	}
+	if _, errs := ruleSet.Apply(ctx, complete); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	incomplete := map[string]any{
+		"next": "/articles?page[number]=2",
+		"last": "/articles?page[number]=9",
+	}
+	_, errs := ruleSet.Apply(ctx, incomplete)
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	unwrapped := errors.Unwrap(errs)
+	if len(unwrapped) != 1 {
+		t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+	}
+	ve := unwrapped[0].(errors.ValidationError)
+	if ve.Path() != "/links" {
+		t.Errorf(`Expected path to be "/links", got: "%s"`, ve.Path())
+	}
+}
+
+// Requirements:
+// - Without options the base URL is returned as a StringLink.
+// - WithQuery merges parameters into any query already on the base URL.
+// - WithMeta returns a link object carrying the meta.
+func TestRelatedLink(t *testing.T) {
+	if link := jsonapi.RelatedLink("https://example.com/stores/1/pets"); link != jsonapi.StringLink("https://example.com/stores/1/pets") {
+		t.Errorf("Expected a plain string link, got: %#v", link)
+	}
+
+	link := jsonapi.RelatedLink("https://example.com/stores/1/pets?include=owner",
+		jsonapi.WithQuery(url.Values{"page[size]": {"10"}}))
+	parsed, err := url.Parse(link.Href())
+	if err != nil {
+		t.Fatalf("Expected a valid URL, got: %s", err)
+	}
+	if got := parsed.Query().Get("include"); got != "owner" {
+		t.Errorf("Expected include=owner to be kept, got: %q", got)
+	}
+	if got := parsed.Query().Get("page[size]"); got != "10" {
+		t.Errorf("Expected page[size]=10, got: %q", got)
+	}
+	if parsed.Path != "/stores/1/pets" {
+		t.Errorf("Expected path /stores/1/pets, got: %q", parsed.Path)
+	}
+
+	link = jsonapi.RelatedLink("https://example.com/stores/1/pets", jsonapi.WithMeta(map[string]any{"count": 3}))
+	full, ok := link.(*jsonapi.FullLink)
+	if !ok {
+		t.Fatalf("Expected a *FullLink, got: %T", link)
+	}
+	if full.Href() != "https://example.com/stores/1/pets" || full.Meta["count"] != 3 {
+		t.Errorf("Unexpected link object: %+v", full)
+	}
+}
+
+// Requirements:
+// - The self link keeps the client's sort, filter, fields, include, and page parameters.
+// - Without parameters, no query string is added.
+func TestSelfLinkWithQuery(t *testing.T) {
+	values, _ := url.ParseQuery("sort=-created,title&filter[author]=9&fields[articles]=title&include=author&page[size]=10")
+	q := jsonapi.QueryDataFromValues(values)
+
+	link := jsonapi.SelfLinkWithQuery("https://example.com", "/articles", q)
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("Expected a valid URL, got: %s", err)
+	}
+	if parsed.Path != "/articles" {
+		t.Errorf("Expected path /articles, got: %q", parsed.Path)
+	}
+	for key, want := range map[string]string{
+		"sort":             "-created,title",
+		"filter[author]":   "9",
+		"fields[articles]": "title",
+		"include":          "author",
+		"page[size]":       "10",
+	} {
+		if got := parsed.Query().Get(key); got != want {
+			t.Errorf("Expected %s=%q, got: %q", key, want, got)
+		}
+	}
+
+	if link := jsonapi.SelfLinkWithQuery("https://example.com", "/articles", jsonapi.QueryData{}); link != "https://example.com/articles" {
+		t.Errorf("Expected no query string, got: %s", link)
+	}
+}
+
+// Requirements:
+// - The self link is built from the scheme, host, path, and query string of the request.
+// - Forwarded headers are only used by SelfLinkFromRequestTrusted.
+// - A forwarded scheme other than http or https is ignored.
+func TestSelfLinkFromRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/articles?include=author&page[size]=10", nil)
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "api.example.org, proxy.internal")
+
+	if self := jsonapi.SelfLinkFromRequest(r).Self(); self != "http://example.com/articles?include=author&page[size]=10" {
+		t.Errorf("Expected the request URL, got: %s", self)
+	}
+
+	if self := jsonapi.SelfLinkFromRequestTrusted(r).Self(); self != "https://api.example.org/articles?include=author&page[size]=10" {
+		t.Errorf("Expected the forwarded scheme and host, got: %s", self)
+	}
+
+	r.Header.Set("X-Forwarded-Proto", "javascript")
+	if self := jsonapi.SelfLinkFromRequestTrusted(r).Self(); self != "http://api.example.org/articles?include=author&page[size]=10" {
+		t.Errorf("Expected the invalid forwarded scheme to be ignored, got: %s", self)
+	}
+
+	r = httptest.NewRequest("GET", "https://example.com/articles/1", nil)
+	if self := jsonapi.SelfLinkFromRequest(r).Self(); self != "https://example.com/articles/1" {
+		t.Errorf("Expected the TLS scheme without forwarded headers, got: %s", self)
+	}
+}
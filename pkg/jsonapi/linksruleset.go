@@ -3,16 +3,29 @@ package jsonapi
 import (
 	"context"
 	"encoding/json"
+	"net/url"
 
 	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
 	"proto.zip/studio/validate/pkg/rules"
 )
 
 // linkCast converts a raw value (string, map, or nil) into a Link for validation.
+// Values that are already links keep their concrete type so they marshal back in the same form.
 func linkCast(ctx context.Context, value any) (Link, errors.ValidationError) {
 	// Link can be a string (StringLink), an object (FullLink), or null (NilLink)
-	if value == nil {
+	switch v := value.(type) {
+	case nil:
 		return NilLink{}, nil
+	case StringLink, NilLink:
+		return v.(Link), nil
+	case *FullLink:
+		if v == nil {
+			return NilLink{}, nil
+		}
+		return v, nil
+	case FullLink:
+		return &v, nil
 	}
 
 	// Convert to JSON bytes to use the custom UnmarshalJSON
@@ -42,3 +55,50 @@ func linkCast(ctx context.Context, value any) (Link, errors.ValidationError) {
 var LinkRuleSet rules.RuleSet[Link] = rules.Interface[Link]().WithCast(linkCast)
 
 var LinksRuleSet *rules.ObjectRuleSet[map[string]Link, string, Link] = rules.StringMap[Link]().WithDynamicKey(rules.String(), LinkRuleSet)
+
+// documentLinkNames are the top-level link names defined by the spec, including the pagination links.
+var documentLinkNames = []string{"self", "related", "describedby", "first", "prev", "next", "last"}
+
+// DocumentLinksRuleSet validates the top-level links of a document. In addition to LinksRuleSet, each
+// link defined by the spec (self, related, describedby, and the pagination links first, prev, next, and
+// last) must be null or have a non-empty href that is a valid URI reference. Errors are reported at
+// /links/<name>.
+var DocumentLinksRuleSet *rules.ObjectRuleSet[map[string]Link, string, Link] = LinksRuleSet.WithRuleFunc(func(ctx context.Context, links map[string]Link) errors.ValidationError {
+	var allErrors []error
+	for _, name := range documentLinkNames {
+		link, ok := links[name]
+		if !ok || link == nil {
+			continue
+		}
+		if _, isNil := link.(NilLink); isNil {
+			continue
+		}
+		linkCtx := rulecontext.WithPathString(ctx, name)
+		href := link.Href()
+		if href == "" {
+			allErrors = append(allErrors, errors.Errorf(errors.CodeRequired, linkCtx, "href required", "Link %q must have an href", name))
+		} else if _, err := url.Parse(href); err != nil {
+			allErrors = append(allErrors, errors.Errorf(errors.CodePattern, linkCtx, "invalid href", "Link %q must be a valid URI reference", name))
+		}
+	}
+	return errors.Join(allErrors...)
+})
+
+// PaginationLinksRule flags incomplete pagination link sets on collection documents: when a next or prev
+// link is present, first and last should be present too. Add it to a links rule set used for responses,
+// e.g. LinksRuleSet.WithRule(PaginationLinksRule). Missing links are reported on the links object itself.
+var PaginationLinksRule rules.Rule[map[string]Link] = rules.RuleFunc[map[string]Link](func(ctx context.Context, links map[string]Link) errors.ValidationError {
+	_, hasNext := links["next"]
+	_, hasPrev := links["prev"]
+	if !hasNext && !hasPrev {
+		return nil
+	}
+
+	var allErrors []error
+	for _, name := range []string{"first", "last"} {
+		if _, ok := links[name]; !ok {
+			allErrors = append(allErrors, errors.Errorf(errors.CodeRequired, ctx, "incomplete pagination links", "Pagination links with next or prev must also include %q", name))
+		}
+	}
+	return errors.Join(allErrors...)
+})
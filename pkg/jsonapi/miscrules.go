@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
 	"proto.zip/studio/validate/pkg/rules"
 )
 
@@ -76,16 +77,35 @@ func (r *resourceLinkageRuleSetImpl) Required() bool {
 var ResourceLinkageRuleSet rules.RuleSet[ResourceLinkage] = &resourceLinkageRuleSetImpl{}
 
 var ResourceIdentifierLinkageRuleSet rules.RuleSet[ResourceIdentifierLinkage] = rules.Struct[ResourceIdentifierLinkage]().
-	WithKey("type", rules.String().Any()).
+	WithKey("type", rules.String().WithRule(typeNameRule{}).Any()).
 	WithKey("id", rules.String().Any()).
 	WithKey("lid", rules.String().Any()).
-	WithKey("meta", rules.StringMap[any]().WithUnknown().Any())
+	WithKey("meta", rules.StringMap[any]().WithUnknown().Any()).
+	WithRuleFunc(evaluateLinkageIdentity)
+
+// evaluateLinkageIdentity requires a type and exactly one of id and lid on a resource identifier.
+// Errors are reported at the offending member, e.g. /data/relationships/author/data/type.
+func evaluateLinkageIdentity(ctx context.Context, linkage ResourceIdentifierLinkage) errors.ValidationError {
+	switch {
+	case linkage.Type == "":
+		return errors.Errorf(errors.CodeRequired, rulecontext.WithPathString(ctx, "type"), "type is required", "resource identifier must have a type")
+	case linkage.ID == "" && linkage.LID == "":
+		return errors.Errorf(errors.CodeRequired, rulecontext.WithPathString(ctx, "id"), "id or lid is required", "resource identifier must have an id or a lid")
+	case linkage.ID != "" && linkage.LID != "":
+		return errors.Errorf(errors.CodeNotAllowed, rulecontext.WithPathString(ctx, "lid"), "id and lid are exclusive", "resource identifier must not have both an id and a lid")
+	}
+	return nil
+}
 
 // relationshipRuleSetImpl is a custom rule set that handles null relationship data properly.
 type relationshipRuleSetImpl struct{}
 
 // Apply validates a relationship object and handles null data by temporarily removing it for Struct validation.
 func (r *relationshipRuleSetImpl) Apply(ctx context.Context, input any) (Relationship, errors.ValidationError) {
+	if errs := evaluateRelationshipMembers(ctx, input); errs != nil {
+		return Relationship{}, errs
+	}
+
 	// Check if input has null data field
 	var hadNullData bool
 	if inputMap, ok := input.(map[string]any); ok {
@@ -119,6 +139,26 @@ func (r *relationshipRuleSetImpl) Apply(ctx context.Context, input any) (Relatio
 	return rel, nil
 }
 
+// evaluateRelationshipMembers requires a relationship object to contain at least one of links, data, or meta.
+// A null data member counts as present, since it empties a to-one relationship.
+func evaluateRelationshipMembers(ctx context.Context, input any) errors.ValidationError {
+	switch v := input.(type) {
+	case map[string]any:
+		for _, member := range []string{"links", "data", "meta"} {
+			if _, ok := v[member]; ok {
+				return nil
+			}
+		}
+	case Relationship:
+		if v.Links != nil || v.Data != nil || v.Meta != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return errors.Errorf(errors.CodeRequired, ctx, "relationship member required", "A relationship must contain at least one of links, data, or meta")
+}
+
 // Evaluate validates a Relationship value and returns any validation errors.
 func (r *relationshipRuleSetImpl) Evaluate(ctx context.Context, value Relationship) errors.ValidationError {
 	_, err := r.Apply(ctx, value)
@@ -161,12 +201,27 @@ var IDRuleSet rules.RuleSet[string] = rules.String().WithStrict()
 
 var MetaRuleSet rules.RuleSet[map[string]any] = rules.StringMap[any]()
 
+// evaluateMetaHook applies a user-supplied meta rule set to meta at the "meta" path.
+// A missing meta object is validated as an empty map so required keys are reported.
+func evaluateMetaHook(ctx context.Context, metaRuleSet rules.RuleSet[map[string]any], meta map[string]any) errors.ValidationError {
+	if metaRuleSet == nil {
+		return nil
+	}
+	if meta == nil {
+		meta = map[string]any{}
+	}
+	_, errs := metaRuleSet.Apply(rulecontext.WithPathString(ctx, "meta"), meta)
+	return errs
+}
+
 // IncludedResourceRuleSet validates a single included resource object
-// Included resources can have any type of attributes, so we validate the basic structure
+// Included resources can have any type of attributes, so we validate the basic structure.
+// Validation stops with a CodeCanceled error once the context is done.
 var IncludedResourceRuleSet rules.RuleSet[map[string]any] = rules.StringMap[any]().
-	WithKey("type", rules.String().Any()).
+	WithKey("type", rules.String().WithRule(typeNameRule{}).Any()).
 	WithKey("id", rules.String().Any()).
-	WithUnknown()
+	WithUnknown().
+	WithRuleFunc(func(ctx context.Context, _ map[string]any) errors.ValidationError { return evaluateCanceled(ctx) })
 
 // IncludedRuleSet validates the included array in a compound document
 var IncludedRuleSet rules.RuleSet[[]any] = rules.Slice[any]().WithItemRuleSet(IncludedResourceRuleSet.Any())
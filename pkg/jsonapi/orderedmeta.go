@@ -0,0 +1,107 @@
+package jsonapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MetaPair is a single member of an ordered meta object.
+type MetaPair struct {
+	Key   string
+	Value any
+}
+
+// OrderedMetaMap is a meta object that marshals its members in insertion order rather than sorted by key,
+// for output that must be reproducible byte for byte, e.g. for caching or signing.
+// Set it as the OrderedMeta of a Datum or document envelope, or use it as a value nested in any meta object.
+// The zero value is an empty map ready to use. The read methods are safe on a nil map, but Set is not.
+type OrderedMetaMap struct {
+	pairs []MetaPair
+}
+
+// OrderedMeta returns an ordered meta object holding pairs in the given order.
+// A key given more than once keeps its first position and its last value.
+func OrderedMeta(pairs ...MetaPair) *OrderedMetaMap {
+	m := &OrderedMetaMap{}
+	for _, pair := range pairs {
+		m.Set(pair.Key, pair.Value)
+	}
+	return m
+}
+
+// Set sets the value of key, appending it if it is not present.
+// Like assigning to a nil Go map, calling Set on a nil *OrderedMetaMap panics; create one with OrderedMeta
+// or take the address of a zero OrderedMetaMap.
+func (m *OrderedMetaMap) Set(key string, value any) {
+	for i := range m.pairs {
+		if m.pairs[i].Key == key {
+			m.pairs[i].Value = value
+			return
+		}
+	}
+	m.pairs = append(m.pairs, MetaPair{Key: key, Value: value})
+}
+
+// Get returns the value of key and whether it is present.
+func (m *OrderedMetaMap) Get(key string) (any, bool) {
+	if m == nil {
+		return nil, false
+	}
+	for _, pair := range m.pairs {
+		if pair.Key == key {
+			return pair.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Len returns the number of members. It is safe to call on a nil map.
+func (m *OrderedMetaMap) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.pairs)
+}
+
+// Pairs returns a copy of the members in order.
+func (m *OrderedMetaMap) Pairs() []MetaPair {
+	if m == nil {
+		return nil
+	}
+	return append([]MetaPair(nil), m.pairs...)
+}
+
+// Map returns the members as a map, e.g. for validation with a meta rule set.
+func (m *OrderedMetaMap) Map() map[string]any {
+	out := make(map[string]any, m.Len())
+	if m != nil {
+		for _, pair := range m.pairs {
+			out[pair.Key] = pair.Value
+		}
+	}
+	return out
+}
+
+// MarshalJSON implements json.Marshaler and writes the members in insertion order.
+func (m *OrderedMetaMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, pair := range m.Pairs() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(pair.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(pair.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
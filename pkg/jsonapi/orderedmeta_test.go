@@ -0,0 +1,93 @@
+package jsonapi_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+)
+
+// Requirements:
+// - Members marshal in insertion order, including nested ordered meta.
+// - A repeated key keeps its first position and its last value.
+// - The zero value accepts Set.
+func TestOrderedMeta_MarshalJSON(t *testing.T) {
+	meta := jsonapi.OrderedMeta(
+		jsonapi.MetaPair{Key: "z", Value: 1},
+		jsonapi.MetaPair{Key: "a", Value: "two"},
+		jsonapi.MetaPair{Key: "m", Value: jsonapi.OrderedMeta(jsonapi.MetaPair{Key: "y", Value: true}, jsonapi.MetaPair{Key: "b", Value: nil})},
+		jsonapi.MetaPair{Key: "z", Value: 3},
+	)
+
+	want := `{"z":3,"a":"two","m":{"y":true,"b":null}}`
+	for run := 0; run < 10; run++ {
+		data, err := json.Marshal(meta)
+		if err != nil {
+			t.Fatalf("Expected marshal error to be nil, got: %s", err)
+		}
+		if string(data) != want {
+			t.Fatalf("Expected %s, got %s", want, data)
+		}
+	}
+	if meta.Len() != 3 {
+		t.Errorf("Expected 3 members, got %d", meta.Len())
+	}
+	if v, ok := meta.Get("a"); !ok || v != "two" {
+		t.Errorf("Expected a to be two, got %v", v)
+	}
+	if m := meta.Map(); len(m) != 3 || m["z"] != 3 {
+		t.Errorf("Expected map with 3 members, got %v", m)
+	}
+
+	var zero jsonapi.OrderedMetaMap
+	zero.Set("k", "v")
+	if data, err := json.Marshal(&zero); err != nil || string(data) != `{"k":"v"}` {
+		t.Errorf(`Expected {"k":"v"} from the zero value, got %s (%v)`, data, err)
+	}
+}
+
+// Requirements:
+// - OrderedMeta on a resource and on a document is emitted as meta in insertion order.
+// - It takes precedence over Meta and keeps its order when members are merged into the document.
+func TestOrderedMeta_DatumAndEnvelope(t *testing.T) {
+	meta := jsonapi.OrderedMeta(jsonapi.MetaPair{Key: "version", Value: 2}, jsonapi.MetaPair{Key: "copyright", Value: "ACME"})
+	want := `"meta":{"version":2,"copyright":"ACME"}`
+
+	datum := jsonapi.Datum[map[string]any]{
+		ID:          "1",
+		Type:        "articles",
+		Attributes:  map[string]any{"title": "Hi"},
+		Meta:        map[string]any{"ignored": true},
+		OrderedMeta: meta,
+	}
+	data, err := json.Marshal(datum)
+	if err != nil {
+		t.Fatalf("Expected marshal error to be nil, got: %s", err)
+	}
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Expected %s in %s", want, data)
+	}
+
+	envelope := jsonapi.SingleDatumEnvelope[map[string]any]{
+		Data:        jsonapi.Datum[map[string]any]{ID: "1", Type: "articles", Attributes: map[string]any{}},
+		AtMembers:   map[string]any{"@context": "https://schema.org"},
+		OrderedMeta: meta,
+	}
+	data, err = json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Expected marshal error to be nil, got: %s", err)
+	}
+	if !strings.Contains(string(data), want) || !strings.Contains(string(data), `"@context"`) {
+		t.Errorf("Expected %s and @context in %s", want, data)
+	}
+
+	collection := jsonapi.DatumCollectionEnvelope[map[string]any]{Data: []jsonapi.Datum[map[string]any]{}, OrderedMeta: meta}
+	data, err = json.Marshal(collection)
+	if err != nil {
+		t.Fatalf("Expected marshal error to be nil, got: %s", err)
+	}
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Expected %s in %s", want, data)
+	}
+}
@@ -0,0 +1,102 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// AnyDatum is a resource object whose attributes are not known ahead of time, such as a member of included.
+type AnyDatum = Datum[map[string]any]
+
+// PolymorphicRuleSet validates resource objects of several types, selecting the attributes rule set by type.
+type PolymorphicRuleSet struct {
+	datumRuleSets map[string]*DatumRuleSet[map[string]any]
+	required      bool
+	rules.NoConflict[AnyDatum]
+}
+
+// NewPolymorphicRuleSet returns a rule set that validates the attributes of each resource object with the
+// registry entry for its type. Types missing from the registry are rejected. Relationships and meta are
+// accepted for every type. Use it as the item rule set of a slice to validate a mixed-type included array:
+//
+//	rules.Slice[jsonapi.AnyDatum]().WithItemRuleSet(jsonapi.NewPolymorphicRuleSet(registry))
+func NewPolymorphicRuleSet(registry map[string]rules.RuleSet[map[string]any]) *PolymorphicRuleSet {
+	datumRuleSets := make(map[string]*DatumRuleSet[map[string]any], len(registry))
+	for typeName, attributesRuleSet := range registry {
+		datumRuleSets[typeName] = NewDatumRuleSet(typeName, attributesRuleSet).
+			WithUnknownRelationships().
+			WithUnknownMeta()
+	}
+	return &PolymorphicRuleSet{datumRuleSets: datumRuleSets}
+}
+
+// WithRequired marks the resource object as required.
+func (ruleSet *PolymorphicRuleSet) WithRequired() *PolymorphicRuleSet {
+	if ruleSet.required {
+		return ruleSet
+	}
+
+	return &PolymorphicRuleSet{datumRuleSets: ruleSet.datumRuleSets, required: true}
+}
+
+// Required reports whether the resource object is required.
+func (ruleSet *PolymorphicRuleSet) Required() bool {
+	return ruleSet.required
+}
+
+// Apply validates the input (JSON string, map, or AnyDatum) with the rule set registered for its type
+// and decodes it into an AnyDatum.
+func (ruleSet *PolymorphicRuleSet) Apply(ctx context.Context, input any) (AnyDatum, errors.ValidationError) {
+	var zero AnyDatum
+
+	if inputStr, ok := input.(string); ok {
+		var decodedInput any
+		if err := json.Unmarshal([]byte(inputStr), &decodedInput); err != nil {
+			return zero, errors.Join(&jsonAPIErrorWrapper{err: MalformedJSONError([]byte(inputStr))})
+		}
+		input = decodedInput
+	}
+
+	var typeName string
+	switch v := input.(type) {
+	case map[string]any:
+		typeName, _ = v["type"].(string)
+	case AnyDatum:
+		typeName = v.Type
+	default:
+		return zero, errors.Errorf(errors.CodeType, ctx, "object", "Resource object must be an object")
+	}
+
+	typeCtx := rulecontext.WithPathString(ctx, "type")
+	if typeName == "" {
+		return zero, errors.Errorf(errors.CodeRequired, typeCtx, "type required", "Resource type is required")
+	}
+	if err := TypeNameRule.Evaluate(typeCtx, typeName); err != nil {
+		return zero, err
+	}
+	datumRuleSet, ok := ruleSet.datumRuleSets[typeName]
+	if !ok {
+		return zero, errors.Errorf(errors.CodeNotAllowed, typeCtx, "unknown type", "Resource type %q is not supported", typeName)
+	}
+	return datumRuleSet.Apply(ctx, input)
+}
+
+// Evaluate validates an AnyDatum value and returns any validation errors.
+func (ruleSet *PolymorphicRuleSet) Evaluate(ctx context.Context, value AnyDatum) errors.ValidationError {
+	_, err := ruleSet.Apply(ctx, value)
+	return err
+}
+
+// Any returns the rule set as rules.RuleSet[any] for use with generic validators.
+func (ruleSet *PolymorphicRuleSet) Any() rules.RuleSet[any] {
+	return rules.WrapAny[AnyDatum](ruleSet)
+}
+
+// String returns a stable name for the rule set for error messages and debugging.
+func (ruleSet *PolymorphicRuleSet) String() string {
+	return "PolymorphicRuleSet"
+}
@@ -0,0 +1,94 @@
+package jsonapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// polymorphicRegistry returns attribute rule sets for two resource types.
+func polymorphicRegistry() map[string]rules.RuleSet[map[string]any] {
+	return map[string]rules.RuleSet[map[string]any]{
+		"articles": rules.StringMap[any]().WithKey("title", rules.String().WithRequired().Any()),
+		"people":   rules.StringMap[any]().WithKey("name", rules.String().WithMinLen(2).Any()),
+	}
+}
+
+// Requirements:
+// - Each resource object is validated with the attributes rule set registered for its type.
+// - The decoded AnyDatum keeps its type and attributes.
+func TestPolymorphicRuleSet_Dispatch(t *testing.T) {
+	ruleSet := jsonapi.NewPolymorphicRuleSet(polymorphicRegistry())
+	ctx := context.Background()
+
+	out, errs := ruleSet.Apply(ctx, `{"type": "people", "id": "9", "attributes": {"name": "Dan"}}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if out.Type != "people" || out.Attributes["name"] != "Dan" {
+		t.Errorf("Expected a people resource named Dan, got: %+v", out)
+	}
+
+	_, errs = ruleSet.Apply(ctx, `{"type": "articles", "id": "1", "attributes": {"name": "Dan"}}`)
+	if errs == nil {
+		t.Fatal("Expected articles attributes to be validated with the articles rule set")
+	}
+}
+
+// Requirements:
+// - A mixed-type included array is validated item by item.
+// - Errors point at the failing item, and unknown or missing types are reported at its type.
+func TestPolymorphicRuleSet_Included(t *testing.T) {
+	included := jsonapi.NewPolymorphicRuleSet(polymorphicRegistry())
+	ruleSet := rules.Slice[jsonapi.AnyDatum]().WithItemRuleSet(included)
+	ctx := context.Background()
+
+	var valid any
+	_ = json.Unmarshal([]byte(`[
+		{"type": "articles", "id": "1", "attributes": {"title": "Hi"}, "relationships": {"author": {"data": {"type": "people", "id": "9"}}}},
+		{"type": "people", "id": "9", "attributes": {"name": "Dan"}, "meta": {"rank": 1}}
+	]`), &valid)
+	out, errs := ruleSet.Apply(ctx, valid)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if len(out) != 2 || out[0].Type != "articles" || out[1].Type != "people" {
+		t.Errorf("Expected articles then people, got: %+v", out)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		code  errors.ErrorCode
+		path  string
+	}{
+		{"attributes", `[{"type": "articles", "id": "1", "attributes": {"title": "Hi"}}, {"type": "people", "id": "9", "attributes": {"name": "D"}}]`, errors.CodeMin, "/1/attributes/name"},
+		{"unknown type", `[{"type": "comments", "id": "5", "attributes": {}}]`, errors.CodeNotAllowed, "/0/type"},
+		{"missing type", `[{"id": "5", "attributes": {}}]`, errors.CodeRequired, "/0/type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input any
+			_ = json.Unmarshal([]byte(tt.input), &input)
+			_, errs := ruleSet.Apply(ctx, input)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			unwrapped := errors.Unwrap(errs)
+			if len(unwrapped) != 1 {
+				t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+			}
+			ve := unwrapped[0].(errors.ValidationError)
+			if ve.Code() != tt.code {
+				t.Errorf("Expected code %s, got: %s", tt.code, ve.Code())
+			}
+			if ve.Path() != tt.path {
+				t.Errorf(`Expected path to be "%s", got: "%s"`, tt.path, ve.Path())
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package jsonapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// ReturnPreference is the return preference of a Prefer request header (RFC 7240),
+// used by clients to ask for a minimal or full response to a write request.
+type ReturnPreference string
+
+const (
+	// ReturnUnspecified means the request did not state a return preference.
+	ReturnUnspecified ReturnPreference = ""
+	// ReturnMinimal asks the server to omit the resource from the response (e.g. 204 No Content).
+	ReturnMinimal ReturnPreference = "minimal"
+	// ReturnRepresentation asks the server to include the full resource in the response.
+	ReturnRepresentation ReturnPreference = "representation"
+)
+
+// parsePreferReturn returns the return preference in a Prefer header value and whether it was present.
+// Preferences are comma-separated; other preferences (e.g. respond-async) and preference parameters are ignored.
+func parsePreferReturn(value string) (string, bool) {
+	for _, preference := range strings.Split(value, ",") {
+		preference, _, _ = strings.Cut(preference, ";")
+		name, token, _ := strings.Cut(preference, "=")
+		if !strings.EqualFold(strings.TrimSpace(name), "return") {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(token), `"`), true
+	}
+	return "", false
+}
+
+// PreferReturnFromHeader returns the return preference of the request headers.
+// ReturnUnspecified is returned when there is no Prefer header, no return preference, or an unknown return value.
+func PreferReturnFromHeader(headers http.Header) ReturnPreference {
+	token, ok := parsePreferReturn(strings.Join(headers.Values("Prefer"), ","))
+	if !ok {
+		return ReturnUnspecified
+	}
+	switch preference := ReturnPreference(strings.ToLower(token)); preference {
+	case ReturnMinimal, ReturnRepresentation:
+		return preference
+	}
+	return ReturnUnspecified
+}
+
+// preferCast validates a Prefer header value and returns its return preference.
+func preferCast(ctx context.Context, value any) (ReturnPreference, errors.ValidationError) {
+	str, ok := value.(string)
+	if !ok {
+		return ReturnUnspecified, errors.Errorf(errors.CodeType, ctx, "string", "Prefer header must be a string")
+	}
+	token, ok := parsePreferReturn(str)
+	if !ok {
+		return ReturnUnspecified, nil
+	}
+	switch preference := ReturnPreference(strings.ToLower(token)); preference {
+	case ReturnMinimal, ReturnRepresentation:
+		return preference, nil
+	}
+	return ReturnUnspecified, errors.Errorf(errors.CodePattern, ctx, "invalid return preference", "Prefer return must be %q or %q, got %q", ReturnMinimal, ReturnRepresentation, token)
+}
+
+// PreferRuleSet validates the return preference of a Prefer header value.
+// Use with HeaderRuleSet.WithPrefer or WithHeader("Prefer", PreferRuleSet.Any()).
+var PreferRuleSet rules.RuleSet[ReturnPreference] = rules.Interface[ReturnPreference]().WithCast(preferCast)
@@ -0,0 +1,108 @@
+package jsonapi_test
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/errors"
+)
+
+// Offset Pagination Tests
+//
+// Offset pagination defines two query parameters:
+// - page[offset]: number of results to skip, a non-negative integer
+// - page[limit]: number of results the client would like to see, from 1 to the server maximum
+
+var offsetRuleSet = jsonapi.QueryStringBaseRuleSet.WithOffsetPagination(50)
+
+// TestOffsetPagination_Valid tests accepted offset and limit values
+func TestOffsetPagination_Valid(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+
+	for _, query := range []string{`page[offset]=0`, `page[offset]=20&page[limit]=10`, `page[limit]=1`, `page[limit]=50`} {
+		t.Run(query, func(t *testing.T) {
+			parsed, _ := url.ParseQuery(query)
+			if _, errs := offsetRuleSet.Apply(ctx, parsed); errs != nil {
+				t.Errorf("%s should be accepted: %s", query, errs)
+			}
+		})
+	}
+}
+
+// TestOffsetPagination_Invalid tests rejected offset and limit values and their error codes
+func TestOffsetPagination_Invalid(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+
+	testCases := []struct {
+		name  string
+		query string
+		code  errors.ErrorCode
+	}{
+		{"negative offset", `page[offset]=-1`, errors.CodeMin},
+		{"zero limit", `page[limit]=0`, errors.CodeMin},
+		{"negative limit", `page[limit]=-5`, errors.CodeMin},
+		{"limit over max", `page[limit]=51`, errors.CodeMax},
+		{"non-integer offset", `page[offset]=ten`, errors.CodeType},
+		{"decimal limit", `page[limit]=10.5`, errors.CodeType},
+		{"empty limit", `page[limit]=`, errors.CodeType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, _ := url.ParseQuery(tc.query)
+			_, errs := offsetRuleSet.Apply(ctx, parsed)
+			if errs == nil {
+				t.Fatalf("%s should be rejected", tc.query)
+			}
+			found := false
+			for _, err := range errors.Unwrap(errs) {
+				if ve, ok := err.(errors.ValidationError); ok && ve.Code() == tc.code {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("Expected %s error for %s, got: %v", tc.code, tc.query, errs)
+			}
+		})
+	}
+}
+
+// TestOffsetPagination_IndexOnly tests that offset and limit are only allowed on index requests
+func TestOffsetPagination_IndexOnly(t *testing.T) {
+	t.Run("page[offset] forbidden on POST", func(t *testing.T) {
+		ctx := jsonapi.WithMethod(context.Background(), "POST")
+		parsed, _ := url.ParseQuery(`page[offset]=10`)
+		if _, errs := offsetRuleSet.Apply(ctx, parsed); errs == nil {
+			t.Errorf("page[offset] should be forbidden on POST")
+		}
+	})
+
+	t.Run("page[limit] forbidden with ID", func(t *testing.T) {
+		ctx := jsonapi.WithMethod(context.Background(), "GET")
+		ctx = jsonapi.WithId(ctx, "123")
+		parsed, _ := url.ParseQuery(`page[limit]=10`)
+		if _, errs := offsetRuleSet.Apply(ctx, parsed); errs == nil {
+			t.Errorf("page[limit] should be forbidden when fetching single resource")
+		}
+	})
+
+	t.Run("HEAD allowed", func(t *testing.T) {
+		ctx := jsonapi.WithMethod(context.Background(), "HEAD")
+		parsed, _ := url.ParseQuery(`page[offset]=10&page[limit]=10`)
+		if _, errs := offsetRuleSet.Apply(ctx, parsed); errs != nil {
+			t.Errorf("offset pagination should be allowed on HEAD: %s", errs)
+		}
+	})
+}
+
+// TestOffsetPagination_Meta tests the response meta helper
+func TestOffsetPagination_Meta(t *testing.T) {
+	want := map[string]any{"total": 120, "offset": 20, "limit": 10}
+	if got := jsonapi.OffsetPaginationMeta(120, 20, 10); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"proto.zip/studio/validate/pkg/errors"
@@ -54,11 +56,231 @@ func NewFieldList(fields ...string) ValueList {
 	return out
 }
 
+type orderedFieldList []string
+
+// Values returns the field names in the order they were given.
+func (fl orderedFieldList) Values() []string {
+	return append([]string{}, fl...)
+}
+
+// Contains reports whether the field list includes the given field name.
+func (fl orderedFieldList) Contains(field string) bool {
+	for _, value := range fl {
+		if value == field {
+			return true
+		}
+	}
+	return false
+}
+
+// doNotExtend prevents external types from satisfying ValueList without the intended methods.
+func (orderedFieldList) doNotExtend() {}
+
+// NewOrderedFieldList returns a ValueList containing the given field names whose Values keeps them
+// in the order given, e.g. to iterate sparse fieldset members in the order the client requested.
+// A field given more than once keeps its first position. The order only affects Values: Datum.MarshalJSON
+// still writes the selected members in sorted key order.
+func NewOrderedFieldList(fields ...string) ValueList {
+	out := make(orderedFieldList, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if !seen[field] {
+			seen[field] = true
+			out = append(out, field)
+		}
+	}
+	return out
+}
+
+type sortedFieldList []string
+
+// Values returns the field names in sorted order.
+func (fl sortedFieldList) Values() []string {
+	return append([]string{}, fl...)
+}
+
+// Contains reports whether the field list includes the given field name.
+func (fl sortedFieldList) Contains(field string) bool {
+	i := sort.SearchStrings(fl, field)
+	return i < len(fl) && fl[i] == field
+}
+
+// doNotExtend prevents external types from satisfying ValueList without the intended methods.
+func (sortedFieldList) doNotExtend() {}
+
+// newSortedFieldList returns a ValueList with the values of list sorted and deduplicated, or nil if list is nil.
+func newSortedFieldList(list ValueList) ValueList {
+	if list == nil {
+		return nil
+	}
+	values := list.Values()
+	sort.Strings(values)
+	out := make(sortedFieldList, 0, len(values))
+	for i, value := range values {
+		if i > 0 && values[i-1] == value {
+			continue
+		}
+		out = append(out, value)
+	}
+	return out
+}
+
+// Include is a relationship path from the include parameter, such as comments.author: Path holds the
+// relationships leading to the included relationship (["comments"]) and Leaf is its name ("author").
 type Include struct {
 	Leaf string
 	Path []string
 }
 
+// ParseInclude parses the value of the include parameter into relationship paths, in request order.
+// Empty items and repeated paths are dropped.
+func ParseInclude(raw string) []Include {
+	var out []Include
+	seen := make(map[string]bool)
+	for _, item := range splitQueryList(raw) {
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		segments := strings.Split(item, ".")
+		include := Include{Leaf: segments[len(segments)-1]}
+		if len(segments) > 1 {
+			include.Path = segments[:len(segments)-1]
+		}
+		out = append(out, include)
+	}
+	return out
+}
+
+// String returns the include path in its query string form, such as comments.author.
+func (include Include) String() string {
+	if len(include.Path) == 0 {
+		return include.Leaf
+	}
+	return strings.Join(include.Path, ".") + "." + include.Leaf
+}
+
+// QueryData holds the standard JSON:API query parameters parsed from a validated query.
+type QueryData struct {
+	// Fields holds the sparse fieldsets keyed by parameter name (e.g. "fields[articles]").
+	Fields map[string]ValueList
+	// Include holds the relationship paths requested with the include parameter, or nil if absent.
+	Include ValueList
+	// Sort holds the sort fields in request order, or nil if absent.
+	Sort []SortParam
+	// Filter holds the filter parameters keyed by parameter name (e.g. "filter[author]"), or nil if absent.
+	Filter map[string]string
+	// Page holds the pagination parameters keyed by parameter name (e.g. "page[size]"), or nil if absent.
+	Page map[string]string
+}
+
+// Normalize returns a copy of the query data with include paths and sparse fieldset lists in sorted order
+// so equivalent queries compare equal (e.g. for building cache keys). Sort order is significant and is kept as is.
+func (q QueryData) Normalize() QueryData {
+	out := QueryData{
+		Include: newSortedFieldList(q.Include),
+		Sort:    append([]SortParam(nil), q.Sort...),
+	}
+	if q.Fields != nil {
+		out.Fields = make(map[string]ValueList, len(q.Fields))
+		for key, list := range q.Fields {
+			out.Fields[key] = newSortedFieldList(list)
+		}
+	}
+	out.Filter = copyStringMap(q.Filter)
+	out.Page = copyStringMap(q.Page)
+	return out
+}
+
+// copyStringMap returns a copy of m, or nil if m is nil.
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// Values encodes the query data back into query parameters. Include paths and sparse fieldsets are
+// written in sorted order; sort fields keep their order.
+func (q QueryData) Values() url.Values {
+	values := make(url.Values)
+	if q.Include != nil {
+		include := q.Include.Values()
+		sort.Strings(include)
+		values.Set("include", strings.Join(include, ","))
+	}
+	if len(q.Sort) > 0 {
+		fields := make([]string, len(q.Sort))
+		for i, param := range q.Sort {
+			fields[i] = param.Field
+			if param.Descending {
+				fields[i] = "-" + param.Field
+			}
+		}
+		values.Set("sort", strings.Join(fields, ","))
+	}
+	for key, list := range q.Fields {
+		if list == nil {
+			continue
+		}
+		fields := list.Values()
+		sort.Strings(fields)
+		values.Set(key, strings.Join(fields, ","))
+	}
+	for key, value := range q.Filter {
+		values.Set(key, value)
+	}
+	for key, value := range q.Page {
+		values.Set(key, value)
+	}
+	return values
+}
+
+// splitQueryList splits a comma-separated query parameter value, dropping empty items.
+func splitQueryList(value string) []string {
+	var out []string
+	for _, item := range strings.Split(value, ",") {
+		if item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// QueryDataFromValues parses the standard parameters of a query into QueryData.
+// Values should already be validated (e.g. with QueryStringBaseRuleSet); only the first value of each parameter is used.
+func QueryDataFromValues(values url.Values) QueryData {
+	out := QueryData{Fields: make(map[string]ValueList)}
+	for key, v := range values {
+		if len(v) == 0 {
+			continue
+		}
+		switch {
+		case key == "include":
+			out.Include = NewOrderedFieldList(splitQueryList(v[0])...)
+		case key == "sort":
+			out.Sort = parseSortParams(v[0])
+		case fieldKeyRule.Evaluate(context.Background(), key) == nil:
+			out.Fields[key] = NewOrderedFieldList(splitQueryList(v[0])...)
+		case filterKeyRule.Evaluate(context.Background(), key) == nil:
+			if out.Filter == nil {
+				out.Filter = make(map[string]string)
+			}
+			out.Filter[key] = v[0]
+		case strings.HasPrefix(key, "page[") && strings.HasSuffix(key, "]"):
+			if out.Page == nil {
+				out.Page = make(map[string]string)
+			}
+			out.Page[key] = v[0]
+		}
+	}
+	return out
+}
+
 // Standard JSON:API query parameter names that are all-lowercase (reserved by spec).
 // Implementation-specific params must contain at least one non-lowercase character.
 var jsonapiStandardLowercaseParams = map[string]bool{
@@ -67,6 +289,9 @@ var jsonapiStandardLowercaseParams = map[string]bool{
 
 // QueryParamNameRule validates that a string is a valid JSON:API query parameter name per the spec.
 // Implementation-specific names must contain at least one character outside a-z (e.g. uppercase, digit, bracket).
+// The standard sort and include names are accepted, as are members of the standard families (e.g. fields[articles],
+// page[size]) and extension parameters in namespace:member form. The bare family names (fields, filter, page)
+// are rejected; a name containing ":" must have a non-empty namespace and member.
 // Use with rules.String().WithRule(QueryParamNameRule) or Evaluate to check before calling WithParam.
 type QueryParamNameRule struct{}
 
@@ -89,6 +314,9 @@ var _ rules.Rule[string] = QueryParamNameRule{}
 // isLegalQueryParamKey reports whether key is legal per JSON:API (implementation-specific
 // params must not be all lowercase). WithParam panics if key is illegal.
 func isLegalQueryParamKey(key string) bool {
+	if namespace, member, ok := strings.Cut(key, ":"); ok && !strings.Contains(namespace, "[") {
+		return namespace != "" && member != ""
+	}
 	for _, r := range key {
 		if r < 'a' || r > 'z' {
 			return true // contains non-a-z → legal (implementation-specific)
@@ -100,42 +328,295 @@ func isLegalQueryParamKey(key string) bool {
 // QueryRuleSet wraps rules/net.QueryRuleSet and adds JSON:API-safe param registration.
 // WithParam panics if the key is illegal per JSON:API (all-lowercase names are reserved).
 type QueryRuleSet struct {
-	inner *rulesnet.QueryRuleSet
+	inner           *rulesnet.QueryRuleSet
+	maxPageSize     int
+	defaultPageSize int
+	mergeRepeated   bool
+}
+
+// DefaultMaxPageSize is the largest page[size] accepted by QueryStringBaseRuleSet unless changed with WithMaxPageSize.
+const DefaultMaxPageSize = 100
+
+// with returns a copy of the rule set using inner, keeping the other settings.
+func (q *QueryRuleSet) with(inner *rulesnet.QueryRuleSet) *QueryRuleSet {
+	return &QueryRuleSet{inner: inner, maxPageSize: q.maxPageSize, defaultPageSize: q.defaultPageSize, mergeRepeated: q.mergeRepeated}
 }
 
 // Query returns a new JSON:API query rule set backed by rules/net.Query().
+// It has no parameters registered; start from QueryStringBaseRuleSet to get the standard JSON:API parameters.
 func Query() *QueryRuleSet {
 	return &QueryRuleSet{inner: rulesnet.Query()}
 }
 
 // WithParam registers a query parameter; panics if key is all-lowercase and not a
 // standard JSON:API param (reserved for future spec use).
+// To restrict a parameter to index GET/HEAD requests like sort, add IndexGETRule to its rule set,
+// e.g. rules.String().WithRule(IndexGETRule[string]()).
 func (q *QueryRuleSet) WithParam(name string, ruleSet rules.RuleSet[any]) *QueryRuleSet {
 	if !isLegalQueryParamKey(name) {
 		panic("jsonapi: query parameter name \"" + name + "\" is illegal per JSON:API spec (all-lowercase names are reserved)")
 	}
-	return &QueryRuleSet{inner: q.inner.WithParam(name, ruleSet)}
+	return q.with(q.inner.WithParam(name, ruleSet))
 }
 
 // WithParamUnsafe registers a query parameter without checking key legality.
 func (q *QueryRuleSet) WithParamUnsafe(name string, ruleSet rules.RuleSet[any]) *QueryRuleSet {
-	return &QueryRuleSet{inner: q.inner.WithParam(name, ruleSet)}
+	return q.with(q.inner.WithParam(name, ruleSet))
 }
 
 // WithRule adds a validation rule over the entire query (url.Values).
 func (q *QueryRuleSet) WithRule(rule rules.Rule[url.Values]) *QueryRuleSet {
-	return &QueryRuleSet{inner: q.inner.WithRule(rule)}
+	return q.with(q.inner.WithRule(rule))
+}
+
+// WithMaxPageSize sets the largest accepted page[size] (DefaultMaxPageSize for QueryStringBaseRuleSet).
+// A value of zero or less removes the limit.
+func (q *QueryRuleSet) WithMaxPageSize(max int) *QueryRuleSet {
+	out := q.with(q.inner)
+	out.maxPageSize = max
+	return out
+}
+
+// WithDefaultPageSize sets the page size returned by PageSize when the query has no page[size].
+func (q *QueryRuleSet) WithDefaultPageSize(size int) *QueryRuleSet {
+	out := q.with(q.inner)
+	out.defaultPageSize = size
+	return out
+}
+
+// WithRepeatedValuesAllowed accepts repeated sort, include, and fields[*] parameters (e.g. include=a&include=b)
+// by comma-joining their values before validation, as if the client had sent include=a,b.
+// By default a repeated parameter is rejected.
+func (q *QueryRuleSet) WithRepeatedValuesAllowed() *QueryRuleSet {
+	out := q.with(q.inner)
+	out.mergeRepeated = true
+	return out
+}
+
+// mergeRepeatedValues returns a copy of values with repeated list parameters comma-joined into one value.
+func mergeRepeatedValues(values url.Values) url.Values {
+	out := make(url.Values, len(values))
+	for key, list := range values {
+		isList := key == "sort" || key == "include" || fieldKeyRule.Evaluate(context.Background(), key) == nil
+		if isList && len(list) > 1 {
+			out[key] = []string{strings.Join(list, ",")}
+			continue
+		}
+		out[key] = list
+	}
+	return out
+}
+
+// prepareInput merges repeated values in input (string or url.Values) when enabled.
+// Other input is returned as-is for the inner rule set to report.
+func (q *QueryRuleSet) prepareInput(input any) any {
+	if !q.mergeRepeated {
+		return input
+	}
+	switch v := input.(type) {
+	case url.Values:
+		return mergeRepeatedValues(v)
+	case string:
+		if values, err := url.ParseQuery(strings.TrimPrefix(v, "?")); err == nil {
+			return mergeRepeatedValues(values)
+		}
+	}
+	return input
+}
+
+// PageSize returns the page[size] of a validated query, or the default page size if it is absent.
+func (q *QueryRuleSet) PageSize(values url.Values) int {
+	if size, err := strconv.Atoi(values.Get("page[size]")); err == nil {
+		return size
+	}
+	return q.defaultPageSize
+}
+
+// evaluateMaxPageSize returns a CodeMax error when page[size] exceeds the configured maximum.
+// Values that are not integers, and requests where page[size] is not allowed, are reported by the page[size] rule set.
+func (q *QueryRuleSet) evaluateMaxPageSize(ctx context.Context, values url.Values) errors.ValidationError {
+	if q.maxPageSize <= 0 || !isIndexGET(ctx) {
+		return nil
+	}
+	size, err := strconv.Atoi(values.Get("page[size]"))
+	if err != nil || size <= q.maxPageSize {
+		return nil
+	}
+	paramCtx := rulecontext.WithPathString(ctx, "query[page[size]]")
+	return errors.Errorf(errors.CodeMax, paramCtx, "page size too large", "page[size] must be at most %d", q.maxPageSize)
+}
+
+// joinValidationErrors combines validation errors, ignoring nil ones. It returns nil if all are nil.
+func joinValidationErrors(errs ...errors.ValidationError) errors.ValidationError {
+	var allErrors []error
+	for _, err := range errs {
+		if err != nil {
+			allErrors = append(allErrors, errors.Unwrap(err)...)
+		}
+	}
+	return errors.Join(allErrors...)
+}
+
+// WithoutSort rejects the sort parameter, for endpoints that do not support sorting.
+// Per JSON:API, a server that does not support sorting must respond 400 when sort is given.
+func (q *QueryRuleSet) WithoutSort() *QueryRuleSet {
+	return q.WithRule(rules.RuleFunc[url.Values](func(ctx context.Context, values url.Values) errors.ValidationError {
+		if _, ok := values["sort"]; !ok {
+			return nil
+		}
+		paramCtx := rulecontext.WithPathString(ctx, "query[sort]")
+		return errors.Errorf(errors.CodeUnexpected, paramCtx, "sort not supported", "Sorting is not supported for this endpoint")
+	}))
+}
+
+// WithoutFilter rejects every filter[*] parameter, for endpoints that do not support filtering.
+func (q *QueryRuleSet) WithoutFilter() *QueryRuleSet {
+	return q.WithRule(rules.RuleFunc[url.Values](func(ctx context.Context, values url.Values) errors.ValidationError {
+		var allErrors []error
+		for _, key := range sortedQueryKeys(values) {
+			if filterKeyRule.Evaluate(ctx, key) != nil {
+				continue
+			}
+			paramCtx := rulecontext.WithPathString(ctx, "query["+key+"]")
+			allErrors = append(allErrors, errors.Errorf(errors.CodeUnexpected, paramCtx, "filter not supported", "Filtering is not supported for this endpoint"))
+		}
+		return errors.Join(allErrors...)
+	}))
+}
+
+// WithMaxIncludeDepth rejects include paths with more than depth relationship names, such as a.b.c with a
+// depth of 2, to bound the cost of resolving nested includes. A depth of zero or less sets no limit.
+func (q *QueryRuleSet) WithMaxIncludeDepth(depth int) *QueryRuleSet {
+	if depth <= 0 {
+		return q
+	}
+	return q.WithRule(rules.RuleFunc[url.Values](func(ctx context.Context, values url.Values) errors.ValidationError {
+		paramCtx := rulecontext.WithPathString(ctx, "query[include]")
+		var allErrors []error
+		for _, value := range values["include"] {
+			for _, path := range strings.Split(value, ",") {
+				if strings.Count(path, ".")+1 > depth {
+					allErrors = append(allErrors, errors.Errorf(errors.CodeMax, paramCtx, "include too deep", "Include path %q must have at most %d segments", path, depth))
+				}
+			}
+		}
+		return errors.Join(allErrors...)
+	}))
+}
+
+// WithMaxFilters rejects queries with more than max filter[*] parameters. A value of zero or less sets no limit.
+func (q *QueryRuleSet) WithMaxFilters(max int) *QueryRuleSet {
+	return q.WithRule(maxFamilyParamsRule(filterKeyRule, "filter", max))
+}
+
+// WithMaxFieldsets rejects queries with more than max fields[*] parameters. A value of zero or less sets no limit.
+func (q *QueryRuleSet) WithMaxFieldsets(max int) *QueryRuleSet {
+	return q.WithRule(maxFamilyParamsRule(fieldKeyRule, "fields", max))
+}
+
+// maxFamilyParamsRule returns a rule that errors with CodeMax when more than max parameters match keyRule.
+// The error is reported at the first parameter, in sorted order, past the limit. A max of zero or less sets no limit.
+func maxFamilyParamsRule(keyRule rules.Rule[string], family string, max int) rules.Rule[url.Values] {
+	return rules.RuleFunc[url.Values](func(ctx context.Context, values url.Values) errors.ValidationError {
+		if max <= 0 {
+			return nil
+		}
+		var keys []string
+		for _, key := range sortedQueryKeys(values) {
+			if keyRule.Evaluate(ctx, key) == nil {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) <= max {
+			return nil
+		}
+		paramCtx := rulecontext.WithPathString(ctx, "query["+keys[max]+"]")
+		return errors.Errorf(errors.CodeMax, paramCtx, "too many "+family+" parameters", "%d %s parameters given, at most %d are allowed", len(keys), family, max)
+	})
+}
+
+// WithFields validates the sparse fieldset for typeName (fields[typeName]) with ruleSet in addition to
+// the standard checks. ruleSet receives each raw comma-separated value as a string.
+func (q *QueryRuleSet) WithFields(typeName string, ruleSet rules.RuleSet[any]) *QueryRuleSet {
+	key := "fields[" + typeName + "]"
+	return q.WithRule(rules.RuleFunc[url.Values](func(ctx context.Context, values url.Values) errors.ValidationError {
+		paramCtx := rulecontext.WithPathString(ctx, "query["+key+"]")
+		var allErrors []error
+		for _, value := range values[key] {
+			if _, errs := ruleSet.Apply(paramCtx, value); errs != nil {
+				allErrors = append(allErrors, errors.Unwrap(errs)...)
+			}
+		}
+		return errors.Join(allErrors...)
+	}))
+}
+
+// WithTypeFields restricts the sparse fieldset for typeName (fields[typeName]) to the given attribute and
+// relationship names. Any other name errors with CodeNotAllowed. Types without registered fields accept any name.
+func (q *QueryRuleSet) WithTypeFields(typeName string, fields ...string) *QueryRuleSet {
+	key := "fields[" + typeName + "]"
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field] = true
+	}
+	return q.WithRule(rules.RuleFunc[url.Values](func(ctx context.Context, values url.Values) errors.ValidationError {
+		paramCtx := rulecontext.WithPathString(ctx, "query["+key+"]")
+		var allErrors []error
+		for _, value := range values[key] {
+			for _, field := range splitQueryList(value) {
+				if !known[field] {
+					allErrors = append(allErrors, errors.Errorf(errors.CodeNotAllowed, paramCtx, "unknown field", "Field %q is not a field of type %q", field, typeName))
+				}
+			}
+		}
+		return errors.Join(allErrors...)
+	}))
+}
+
+// sortedQueryKeys returns the parameter names in values in sorted order so errors are reported deterministically.
+func sortedQueryKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // Apply implements rules.RuleSet[url.Values].
 func (q *QueryRuleSet) Apply(ctx context.Context, input any) (url.Values, errors.ValidationError) {
-	out, err := q.inner.Apply(ctx, input)
+	if isOptions(ctx) {
+		if values, ok := optionsQueryValues(input); ok {
+			return values, nil
+		}
+	}
+	out, err := q.inner.Apply(ctx, q.prepareInput(input))
+	err = joinValidationErrors(err, q.evaluateMaxPageSize(ctx, out))
 	return out, ToJSONAPIErrors(err, SourceParameter)
 }
 
 // Evaluate implements rules.RuleSet[url.Values].
 func (q *QueryRuleSet) Evaluate(ctx context.Context, values url.Values) errors.ValidationError {
-	return ToJSONAPIErrors(q.inner.Evaluate(ctx, values), SourceParameter)
+	if isOptions(ctx) {
+		return nil
+	}
+	if q.mergeRepeated {
+		values = mergeRepeatedValues(values)
+	}
+	err := joinValidationErrors(q.inner.Evaluate(ctx, values), q.evaluateMaxPageSize(ctx, values))
+	return ToJSONAPIErrors(err, SourceParameter)
+}
+
+// optionsQueryValues converts the input of an OPTIONS request to url.Values without validating it.
+func optionsQueryValues(input any) (url.Values, bool) {
+	switch v := input.(type) {
+	case url.Values:
+		return v, true
+	case string:
+		values, err := url.ParseQuery(strings.TrimPrefix(v, "?"))
+		return values, err == nil
+	}
+	return nil, false
 }
 
 // Required implements rules.RuleSet[url.Values].
@@ -182,7 +663,7 @@ var fieldsRuleSet = rules.Interface[ValueList]().WithCast(func(ctx context.Conte
 
 	splitStrs := strings.Split(strs[0], ",")
 
-	return NewFieldList(splitStrs...), nil
+	return NewOrderedFieldList(splitStrs...), nil
 })
 
 var includeRuleSet = fieldsRuleSet
@@ -190,10 +671,7 @@ var includeRuleSet = fieldsRuleSet
 var sortRuleSet = rules.Interface[[]SortParam]().WithCast(func(ctx context.Context, value any) ([]SortParam, errors.ValidationError) {
 
 	// Sort is only allowed on index GET requests
-	method := MethodFromContext(ctx)
-	id := IdFromContext(ctx)
-
-	if method != "" && (id != "" || (method != "GET" && method != "HEAD")) {
+	if !isIndexGET(ctx) {
 		return nil, errors.Errorf(errors.CodeForbidden, ctx, "Sort forbidden", "Sort is only allowed on index GET requests")
 	}
 
@@ -202,7 +680,12 @@ var sortRuleSet = rules.Interface[[]SortParam]().WithCast(func(ctx context.Conte
 		return nil, verrs
 	}
 
-	itms := strings.Split(strs[0], ",")
+	return parseSortParams(strs[0]), nil
+})
+
+// parseSortParams splits a sort parameter value into sort fields; a leading "-" marks descending order.
+func parseSortParams(value string) []SortParam {
+	itms := strings.Split(value, ",")
 
 	out := make([]SortParam, len(itms))
 
@@ -223,13 +706,116 @@ var sortRuleSet = rules.Interface[[]SortParam]().WithCast(func(ctx context.Conte
 		}
 	}
 
-	return out, nil
-})
+	return out
+}
 
-var pageSizeRuleSet = intQueryValueRuleSet.WithRule(HTTPMethodRule[[]int, string]("GET", "HEAD")).WithRule(IndexRule[[]int, string]()).WithItemRuleSet(rules.Int().WithMin(1).WithMax(100)).Any()
+// evaluateIntQueryValue rejects values that are not integers or do not fit in an int, so huge values
+// such as page[size]=99999999999999999999 are reported cleanly instead of overflowing.
+func evaluateIntQueryValue(ctx context.Context, value string) errors.ValidationError {
+	if _, err := strconv.Atoi(value); err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			if strings.HasPrefix(value, "-") {
+				return errors.Errorf(errors.CodeMin, ctx, "value out of range", "value %q is below the minimum integer", value)
+			}
+			return errors.Errorf(errors.CodeMax, ctx, "value out of range", "value %q exceeds the maximum integer", value)
+		}
+		return errors.Errorf(errors.CodeType, ctx, "integer expected", "value %q is not an integer", value)
+	}
+	return nil
+}
+
+var pageSizeRuleSet = intQueryValueRuleSet.WithRule(HTTPMethodRule[[]int, string]("GET", "HEAD")).WithRule(IndexRule[[]int, string]()).WithItemRuleSet(rules.Int().WithMin(1)).Any()
 
 var cursorRuleSet = rules.Slice[string]().WithItemRuleSet(rules.String().WithMinLen(1)).WithMaxLen(1).WithMinLen(1).WithRule(HTTPMethodRule[[]string, string]("GET", "HEAD")).WithRule(IndexRule[[]string, string]()).Any()
 
+// SortFieldsRule returns a rule that checks every field in the sort parameter against the given fields.
+// Fields are attribute names of the primary type or relationship paths to attributes (e.g. "author.name").
+// A leading "-" (descending) is ignored. Unknown fields are reported on source.parameter "sort".
+func SortFieldsRule(fields ...string) rules.Rule[url.Values] {
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field] = true
+	}
+
+	return rules.RuleFunc[url.Values](func(ctx context.Context, values url.Values) errors.ValidationError {
+		sortValues, ok := values["sort"]
+		if !ok {
+			return nil
+		}
+
+		var allErrors []error
+		paramCtx := rulecontext.WithPathString(ctx, "query[sort]")
+		for _, sortValue := range sortValues {
+			for _, field := range strings.Split(sortValue, ",") {
+				field = strings.TrimPrefix(field, "-")
+				if field == "" || known[field] {
+					continue
+				}
+				allErrors = append(allErrors, errors.Errorf(errors.CodeUnexpected, paramCtx, "unknown sort field", "sort field %q is not a known attribute or relationship path", field))
+			}
+		}
+		return errors.Join(allErrors...)
+	})
+}
+
+// CursorPaginationParams are the page parameters specific to the cursor pagination profile.
+// page[size] is shared with other pagination styles and is not included.
+var CursorPaginationParams = []string{"page[after]", "page[before]"}
+
+// PageNumberPaginationParams are the page parameters specific to page-number pagination.
+var PageNumberPaginationParams = []string{"page[number]"}
+
+// OffsetPaginationParams are the page parameters specific to offset/limit pagination.
+var OffsetPaginationParams = []string{"page[offset]", "page[limit]"}
+
+var pageOffsetRuleSet = intQueryValueRuleSet.WithRule(HTTPMethodRule[[]int, string]("GET", "HEAD")).WithRule(IndexRule[[]int, string]()).WithItemRuleSet(rules.Int().WithMin(0)).Any()
+
+// WithOffsetPagination registers the offset pagination parameters: page[offset], a non-negative integer,
+// and page[limit], an integer from 1 to maxLimit. Like page[size], both are only allowed on index GET
+// and HEAD requests. Use OffsetPaginationMeta to describe the page in the response.
+func (q *QueryRuleSet) WithOffsetPagination(maxLimit int) *QueryRuleSet {
+	limitRuleSet := intQueryValueRuleSet.WithRule(HTTPMethodRule[[]int, string]("GET", "HEAD")).WithRule(IndexRule[[]int, string]()).WithItemRuleSet(rules.Int().WithMin(1).WithMax(maxLimit)).Any()
+	return q.
+		WithParamUnsafe("page[offset]", &queryParamAdapter{inner: pageOffsetRuleSet, check: evaluateIntQueryValue}).
+		WithParamUnsafe("page[limit]", &queryParamAdapter{inner: limitRuleSet, check: evaluateIntQueryValue})
+}
+
+// OffsetPaginationMeta returns a meta object describing an offset-paginated response,
+// with the total number of results and the offset and limit of the page.
+func OffsetPaginationMeta(total, offset, limit int) map[string]any {
+	return map[string]any{
+		"total":  total,
+		"offset": offset,
+		"limit":  limit,
+	}
+}
+
+// PaginationFamilyRule returns a rule that allows parameters from at most one pagination family.
+// Each family lists the parameters that belong to one pagination style (e.g. CursorPaginationParams);
+// parameters shared between styles, such as page[size], should not be listed.
+// When several families are present, the first one in declaration order is kept and every parameter
+// from the other families is reported as an error on its own source.parameter.
+func PaginationFamilyRule(families ...[]string) rules.Rule[url.Values] {
+	return rules.RuleFunc[url.Values](func(ctx context.Context, values url.Values) errors.ValidationError {
+		var allErrors []error
+		selected := ""
+		for _, family := range families {
+			for _, key := range family {
+				if _, ok := values[key]; !ok {
+					continue
+				}
+				if selected == "" {
+					selected = key
+					break
+				}
+				paramCtx := rulecontext.WithPathString(ctx, "query["+key+"]")
+				allErrors = append(allErrors, errors.Errorf(errors.CodeUnexpected, paramCtx, "conflicting pagination parameters", "query parameter %q cannot be combined with %q", key, selected))
+			}
+		}
+		return errors.Join(allErrors...)
+	})
+}
+
 // jsonAPIQueryRule validates dynamic keys (fields[*], filter[*], ext) and rejects unknown all-lowercase params.
 func jsonAPIQueryRule(ctx context.Context, values url.Values) errors.ValidationError {
 	var allErrors []error
@@ -259,13 +845,20 @@ func jsonAPIQueryRule(ctx context.Context, values url.Values) errors.ValidationE
 
 // queryParamAdapter adapts rule sets that expect []string (from url.Values) to accept
 // a single string (as passed by rules/net.QueryRuleSet per param).
+// If check is set it is run on the raw string before delegating.
 type queryParamAdapter struct {
 	inner rules.RuleSet[any]
+	check func(ctx context.Context, value string) errors.ValidationError
 }
 
 // Apply converts a single string value to []string and delegates to the inner rule set.
 func (a *queryParamAdapter) Apply(ctx context.Context, value any) (any, errors.ValidationError) {
 	if s, ok := value.(string); ok {
+		if a.check != nil {
+			if err := a.check(ctx, s); err != nil {
+				return nil, err
+			}
+		}
 		value = []string{s}
 	}
 	return a.inner.Apply(ctx, value)
@@ -274,6 +867,11 @@ func (a *queryParamAdapter) Apply(ctx context.Context, value any) (any, errors.V
 // Evaluate converts a single string value to []string and delegates to the inner rule set.
 func (a *queryParamAdapter) Evaluate(ctx context.Context, value any) errors.ValidationError {
 	if s, ok := value.(string); ok {
+		if a.check != nil {
+			if err := a.check(ctx, s); err != nil {
+				return err
+			}
+		}
 		value = []string{s}
 	}
 	return a.inner.Evaluate(ctx, value)
@@ -296,7 +894,8 @@ func (a *queryParamAdapter) Any() rules.RuleSet[any] { return a }
 var QueryStringBaseRuleSet *QueryRuleSet = Query().
 	WithParam("sort", &queryParamAdapter{inner: sortRuleSet.Any()}).
 	WithParam("include", &queryParamAdapter{inner: includeRuleSet.Any()}).
-	WithParamUnsafe("page[size]", &queryParamAdapter{inner: pageSizeRuleSet}).
+	WithParamUnsafe("page[size]", &queryParamAdapter{inner: pageSizeRuleSet, check: evaluateIntQueryValue}).
 	WithParamUnsafe("page[after]", &queryParamAdapter{inner: cursorRuleSet}).
 	WithParamUnsafe("page[before]", &queryParamAdapter{inner: cursorRuleSet}).
-	WithRule(rules.RuleFunc[url.Values](jsonAPIQueryRule))
+	WithRule(rules.RuleFunc[url.Values](jsonAPIQueryRule)).
+	WithMaxPageSize(DefaultMaxPageSize)
@@ -3,6 +3,8 @@ package jsonapi_test
 import (
 	"context"
 	"net/url"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -159,11 +161,11 @@ func TestQueryUnexpected(t *testing.T) {
 func TestQueryParamNameRule(t *testing.T) {
 	rule := jsonapi.QueryParamNameRule{}
 
-	valid := []string{"sort", "include", "page[size]", "fields[articles]", "filter[x]", "ext:foo", "camelCase", "my_param"}
+	valid := []string{"sort", "include", "page[size]", "fields[articles]", "filter[x]", "filter[a:b]", "ext:foo", "camelCase", "my_param"}
 	for _, name := range valid {
 		testhelpers.MustEvaluate(t, rule, name)
 	}
-	invalid := []string{"foo", "unknownparam", "bar", "filter", "page", "fields"}
+	invalid := []string{"foo", "unknownparam", "bar", "filter", "page", "fields", "ext:", ":member", ""}
 	for _, name := range invalid {
 		testhelpers.MustNotEvaluate(t, rule, name, errors.CodeUnexpected)
 	}
@@ -199,6 +201,55 @@ func TestFieldListMap_doNotExtend(t *testing.T) {
 	}
 }
 
+// Requirements:
+// - NewOrderedFieldList returns values in the order given, without duplicates.
+// - Parsed sparse fieldsets keep the order of the query string.
+func TestNewOrderedFieldList(t *testing.T) {
+	fl := jsonapi.NewOrderedFieldList("z", "a", "m", "a")
+	if values := fl.Values(); !reflect.DeepEqual(values, []string{"z", "a", "m"}) {
+		t.Errorf("Expected [z a m], got %v", values)
+	}
+	if !fl.Contains("m") || fl.Contains("b") {
+		t.Errorf("Expected Contains to report m and not b, got %v", fl.Values())
+	}
+
+	parsed, err := url.ParseQuery(`fields[articles]=z,a,m`)
+	if err != nil {
+		t.Fatalf("Expected parse error to be nil, got: %s", err)
+	}
+	query := jsonapi.QueryDataFromValues(parsed)
+	if values := query.Fields["fields[articles]"].Values(); !reflect.DeepEqual(values, []string{"z", "a", "m"}) {
+		t.Errorf("Expected [z a m], got %v", values)
+	}
+}
+
+// Requirements:
+// - ParseInclude splits each path into its relationship path and leaf.
+// - Include.String reconstructs each path, so joining them round-trips the include value.
+// - Empty items and repeated paths are dropped.
+func TestParseInclude(t *testing.T) {
+	includes := jsonapi.ParseInclude("author,comments.author")
+	expected := []jsonapi.Include{
+		{Leaf: "author"},
+		{Leaf: "author", Path: []string{"comments"}},
+	}
+	if !reflect.DeepEqual(includes, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, includes)
+	}
+
+	paths := make([]string, len(includes))
+	for i, include := range includes {
+		paths[i] = include.String()
+	}
+	if raw := strings.Join(paths, ","); raw != "author,comments.author" {
+		t.Errorf("Expected author,comments.author, got %q", raw)
+	}
+
+	if includes := jsonapi.ParseInclude("author,,author"); len(includes) != 1 {
+		t.Errorf("Expected 1 include, got %+v", includes)
+	}
+}
+
 func TestQueryStringFields_DELETE_Forbidden(t *testing.T) {
 	qs := `fields[articles]=abc,xyz`
 	parsed, err := url.ParseQuery(qs)
@@ -425,3 +476,378 @@ func TestQueryStringPageSize_InvalidRange(t *testing.T) {
 		t.Fatalf("Expected validation error for page[size]=101, got nil")
 	}
 }
+
+// Requirements:
+// - Values that overflow int are rejected with CodeMax (or CodeMin when negative).
+// - Non-integer values are rejected with CodeType.
+// - Errors are reported at source.parameter page[size].
+func TestQueryStringPageSize_Overflow(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+
+	tests := []struct {
+		qs   string
+		code errors.ErrorCode
+	}{
+		{"page[size]=99999999999999999999", errors.CodeMax},
+		{"page[size]=-99999999999999999999", errors.CodeMin},
+		{"page[size]=ten", errors.CodeType},
+	}
+	for _, tt := range tests {
+		parsed, _ := url.ParseQuery(tt.qs)
+		_, errs := jsonapi.QueryStringBaseRuleSet.Apply(ctx, parsed)
+		if errs == nil {
+			t.Errorf("Expected validation error for %s, got nil", tt.qs)
+			continue
+		}
+		list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourceParameter)
+		if len(list) != 1 {
+			t.Errorf("%s: expected 1 error, got %d", tt.qs, len(list))
+			continue
+		}
+		if list[0].Code != string(tt.code) {
+			t.Errorf("%s: expected code %s, got %s", tt.qs, tt.code, list[0].Code)
+		}
+		if list[0].Source == nil || list[0].Source.Parameter != "page[size]" {
+			t.Errorf("%s: expected source.parameter page[size], got %+v", tt.qs, list[0].Source)
+		}
+	}
+}
+
+// Requirements:
+// - Parameters from a single pagination family are accepted.
+// - Mixing families reports the conflicting parameter at source.parameter.
+func TestPaginationFamilyRule(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+	ruleSet := jsonapi.QueryStringBaseRuleSet.WithRule(jsonapi.PaginationFamilyRule(jsonapi.CursorPaginationParams, jsonapi.PageNumberPaginationParams))
+
+	for _, qs := range []string{"page[after]=abc&page[size]=10", "page[number]=2&page[size]=10", "page[before]=abc&page[after]=xyz"} {
+		parsed, _ := url.ParseQuery(qs)
+		if _, errs := ruleSet.Apply(ctx, parsed); errs != nil {
+			t.Errorf("Expected %q to be valid, got: %s", qs, errs)
+		}
+	}
+
+	parsed, _ := url.ParseQuery("page[number]=2&page[after]=abc")
+	_, errs := ruleSet.Apply(ctx, parsed)
+	if errs == nil {
+		t.Fatal("Expected error when mixing page[number] and page[after]")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourceParameter)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Source == nil || list[0].Source.Parameter != "page[number]" {
+		t.Errorf("Expected source.parameter page[number], got %+v", list[0].Source)
+	}
+}
+
+// Requirements:
+// - Known attribute and relationship path fields are accepted, ascending or descending.
+// - Unknown fields error at source.parameter "sort" naming the field.
+func TestSortFieldsRule(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+	ruleSet := jsonapi.QueryStringBaseRuleSet.WithRule(jsonapi.SortFieldsRule("title", "created", "author.name"))
+
+	parsed, _ := url.ParseQuery("sort=-created,title,author.name")
+	if _, errs := ruleSet.Apply(ctx, parsed); errs != nil {
+		t.Errorf("Expected known sort fields to be valid, got: %s", errs)
+	}
+
+	parsed, _ = url.ParseQuery("sort=title,-nonexistent")
+	_, errs := ruleSet.Apply(ctx, parsed)
+	if errs == nil {
+		t.Fatal("Expected error for unknown sort field")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourceParameter)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Source == nil || list[0].Source.Parameter != "sort" {
+		t.Errorf("Expected source.parameter sort, got %+v", list[0].Source)
+	}
+	if !strings.Contains(list[0].Detail, "nonexistent") {
+		t.Errorf("Expected detail to name the unknown field, got %q", list[0].Detail)
+	}
+}
+
+// Requirements:
+// - Equivalent include and fields values normalize to the same form.
+// - Normalized lists are sorted.
+// - Sort order is preserved.
+func TestQueryData_Normalize(t *testing.T) {
+	a := jsonapi.QueryDataFromValues(url.Values{
+		"include":          []string{"comments,author"},
+		"fields[articles]": []string{"title,body"},
+		"sort":             []string{"-created,title"},
+	}).Normalize()
+	b := jsonapi.QueryDataFromValues(url.Values{
+		"include":          []string{"author,comments"},
+		"fields[articles]": []string{"body,title"},
+		"sort":             []string{"-created,title"},
+	}).Normalize()
+
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected equivalent queries to normalize to the same form, got %v and %v", a, b)
+	}
+	if values := a.Include.Values(); !reflect.DeepEqual(values, []string{"author", "comments"}) {
+		t.Errorf("Expected sorted include paths, got %v", values)
+	}
+	if values := a.Fields["fields[articles]"].Values(); !reflect.DeepEqual(values, []string{"body", "title"}) {
+		t.Errorf("Expected sorted fields, got %v", values)
+	}
+	if !a.Include.Contains("comments") || a.Include.Contains("tags") {
+		t.Errorf("Expected normalized include to keep its members, got %v", a.Include.Values())
+	}
+	if len(a.Sort) != 2 || a.Sort[0].Field != "created" || !a.Sort[0].Descending || a.Sort[1].Field != "title" {
+		t.Errorf("Expected sort order to be preserved, got %v", a.Sort)
+	}
+}
+
+// Requirements:
+// - WithoutSort rejects the sort parameter on source.parameter "sort".
+// - WithoutFilter rejects filter[*] parameters.
+// - WithFields applies the rule set to fields[type] only.
+func TestQueryRuleSet_Builders(t *testing.T) {
+	fieldNames := rules.String().WithRegexp(regexp.MustCompile(`^(title|body)(,(title|body))*$`), "").Any()
+	rs := jsonapi.QueryStringBaseRuleSet.
+		WithoutSort().
+		WithoutFilter().
+		WithFields("articles", fieldNames)
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+
+	if _, errs := rs.Apply(ctx, "fields[articles]=title,body&fields[people]=name&include=author"); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	tests := []struct {
+		query     string
+		parameter string
+	}{
+		{"sort=title", "sort"},
+		{"filter[author]=1", "filter[author]"},
+		{"fields[articles]=title,secret", "fields[articles]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, errs := rs.Apply(ctx, tt.query)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourceParameter)
+			if len(list) != 1 {
+				t.Fatalf("Expected 1 error, got %d: %v", len(list), list)
+			}
+			if list[0].Source == nil || list[0].Source.Parameter != tt.parameter {
+				t.Errorf("Expected parameter %s, got %+v", tt.parameter, list[0].Source)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - WithTypeFields accepts registered field names.
+// - An unknown field name errors with CodeNotAllowed on source.parameter fields[articles].
+// - Fieldsets for unregistered types accept any name.
+func TestQueryRuleSet_WithTypeFields(t *testing.T) {
+	rs := jsonapi.QueryStringBaseRuleSet.WithTypeFields("articles", "title", "body")
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+
+	if _, errs := rs.Apply(ctx, "fields[articles]=title,body&fields[people]=anything"); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	_, errs := rs.Apply(ctx, "fields[articles]=title,bogus")
+	if errs == nil {
+		t.Fatal("Expected errors to not be nil")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourceParameter)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(list), list)
+	}
+	if list[0].Code != string(errors.CodeNotAllowed) {
+		t.Errorf("Expected code %s, got %s", errors.CodeNotAllowed, list[0].Code)
+	}
+	if list[0].Source == nil || list[0].Source.Parameter != "fields[articles]" {
+		t.Errorf("Expected parameter fields[articles], got %+v", list[0].Source)
+	}
+}
+
+// Requirements:
+// - The default maximum page size is 100.
+// - WithMaxPageSize changes the limit and the error detail names it.
+// - PageSize falls back to the configured default.
+func TestQueryRuleSet_WithMaxPageSize(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+	ruleSet := jsonapi.QueryStringBaseRuleSet.WithMaxPageSize(25).WithDefaultPageSize(10)
+
+	parsed, _ := url.ParseQuery("page[size]=25")
+	if _, errs := ruleSet.Apply(ctx, parsed); errs != nil {
+		t.Errorf("Expected page[size]=25 to be valid, got: %s", errs)
+	}
+	if size := ruleSet.PageSize(parsed); size != 25 {
+		t.Errorf("Expected page size 25, got %d", size)
+	}
+	if size := ruleSet.PageSize(url.Values{}); size != 10 {
+		t.Errorf("Expected default page size 10, got %d", size)
+	}
+
+	parsed, _ = url.ParseQuery("page[size]=26")
+	_, errs := ruleSet.Apply(ctx, parsed)
+	if errs == nil {
+		t.Fatal("Expected page[size]=26 to be rejected")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourceParameter)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Code != string(errors.CodeMax) {
+		t.Errorf("Expected code %s, got %s", errors.CodeMax, list[0].Code)
+	}
+	if !strings.Contains(list[0].Detail, "25") {
+		t.Errorf("Expected detail to name the limit, got %q", list[0].Detail)
+	}
+	if list[0].Source == nil || list[0].Source.Parameter != "page[size]" {
+		t.Errorf("Expected source.parameter page[size], got %+v", list[0].Source)
+	}
+
+	parsed, _ = url.ParseQuery("page[size]=150")
+	if _, errs := jsonapi.QueryStringBaseRuleSet.WithMaxPageSize(200).Apply(ctx, parsed); errs != nil {
+		t.Errorf("Expected page[size]=150 to be valid with a max of 200, got: %s", errs)
+	}
+	if _, errs := jsonapi.QueryStringBaseRuleSet.Apply(ctx, parsed); errs == nil {
+		t.Error("Expected page[size]=150 to exceed the default max")
+	}
+}
+
+// Requirements:
+// - Include paths up to the maximum depth pass.
+// - Deeper paths error with CodeMax and source.parameter include.
+// - A depth of zero or less sets no limit.
+func TestQueryRuleSet_WithMaxIncludeDepth(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+	ruleSet := jsonapi.QueryStringBaseRuleSet.WithMaxIncludeDepth(2)
+
+	parsed, _ := url.ParseQuery("include=author,comments.author")
+	if _, errs := ruleSet.Apply(ctx, parsed); errs != nil {
+		t.Errorf("Expected include depth 2 to be valid, got: %s", errs)
+	}
+
+	parsed, _ = url.ParseQuery("include=author,a.b.c")
+	_, errs := ruleSet.Apply(ctx, parsed)
+	if errs == nil {
+		t.Fatal("Expected include=a.b.c to be rejected")
+	}
+	list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourceParameter)
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(list))
+	}
+	if list[0].Code != string(errors.CodeMax) {
+		t.Errorf("Expected code %s, got %s", errors.CodeMax, list[0].Code)
+	}
+	if list[0].Source == nil || list[0].Source.Parameter != "include" {
+		t.Errorf("Expected source.parameter include, got %+v", list[0].Source)
+	}
+
+	for _, depth := range []int{0, -1} {
+		if _, errs := jsonapi.QueryStringBaseRuleSet.WithMaxIncludeDepth(depth).Apply(ctx, parsed); errs != nil {
+			t.Errorf("Expected depth %d to set no limit, got: %s", depth, errs)
+		}
+	}
+}
+
+// Requirements:
+// - Filters and fieldsets up to the maximum pass.
+// - One more errors with CodeMax, and the detail states the count.
+// - A max of zero or less sets no limit.
+func TestQueryRuleSet_WithMaxFiltersAndFieldsets(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+	ruleSet := jsonapi.QueryStringBaseRuleSet.WithMaxFilters(2).WithMaxFieldsets(1)
+
+	parsed, _ := url.ParseQuery("filter[a]=1&filter[b]=2&fields[articles]=title")
+	if _, errs := ruleSet.Apply(ctx, parsed); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	tests := []struct {
+		query     string
+		parameter string
+		count     string
+	}{
+		{"filter[a]=1&filter[b]=2&filter[c]=3", "filter[c]", "3 filter"},
+		{"fields[articles]=title&fields[people]=name", "fields[people]", "2 fields"},
+	}
+	for _, tt := range tests {
+		parsed, _ := url.ParseQuery(tt.query)
+		_, errs := ruleSet.Apply(ctx, parsed)
+		if errs == nil {
+			t.Errorf("Expected %s to be rejected", tt.query)
+			continue
+		}
+		list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourceParameter)
+		if len(list) != 1 {
+			t.Errorf("%s: expected 1 error, got %d", tt.query, len(list))
+			continue
+		}
+		if list[0].Code != string(errors.CodeMax) {
+			t.Errorf("%s: expected code %s, got %s", tt.query, errors.CodeMax, list[0].Code)
+		}
+		if !strings.Contains(list[0].Detail, tt.count) {
+			t.Errorf("%s: expected detail to contain %q, got %q", tt.query, tt.count, list[0].Detail)
+		}
+		if list[0].Source == nil || list[0].Source.Parameter != tt.parameter {
+			t.Errorf("%s: expected source.parameter %s, got %+v", tt.query, tt.parameter, list[0].Source)
+		}
+	}
+
+	parsed, _ = url.ParseQuery("filter[a]=1&filter[b]=2&fields[articles]=title&fields[people]=name")
+	for _, max := range []int{0, -1} {
+		unlimited := jsonapi.QueryStringBaseRuleSet.WithMaxFilters(max).WithMaxFieldsets(max)
+		if _, errs := unlimited.Apply(ctx, parsed); errs != nil {
+			t.Errorf("Expected max %d to set no limit, got: %s", max, errs)
+		}
+	}
+}
+
+// Requirements:
+// - A repeated include is rejected by default.
+// - With WithRepeatedValuesAllowed, repeated values are comma-joined and parsed as one list.
+func TestQueryRuleSet_WithRepeatedValuesAllowed(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "GET")
+	parsed, _ := url.ParseQuery("include=a&include=b")
+
+	if _, errs := jsonapi.QueryStringBaseRuleSet.Apply(ctx, parsed); errs == nil {
+		t.Error("Expected a repeated include to be rejected by default")
+	}
+
+	ruleSet := jsonapi.QueryStringBaseRuleSet.WithRepeatedValuesAllowed()
+	values, errs := ruleSet.Apply(ctx, parsed)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if got := values["include"]; len(got) != 1 || got[0] != "a,b" {
+		t.Errorf("Expected include to be merged into [a,b], got %v", got)
+	}
+	if include := jsonapi.QueryDataFromValues(values).Include; !include.Contains("a") || !include.Contains("b") {
+		t.Errorf("Expected include a and b, got %v", include)
+	}
+
+	if _, errs := ruleSet.Apply(ctx, "sort=title&sort=-created"); errs != nil {
+		t.Errorf("Expected a repeated sort string to be accepted, got: %s", errs)
+	}
+	if errs := ruleSet.Evaluate(ctx, parsed); errs != nil {
+		t.Errorf("Expected Evaluate to accept repeated values, got: %s", errs)
+	}
+}
+
+// Requirements:
+// - Under OPTIONS, query parameters are returned without validation.
+func TestQueryRuleSet_OptionsSkipsValidation(t *testing.T) {
+	ctx := jsonapi.WithMethod(context.Background(), "OPTIONS")
+	values, errs := jsonapi.QueryStringBaseRuleSet.Apply(ctx, "page[size]=1000&foo=bar")
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if values.Get("page[size]") != "1000" {
+		t.Errorf("Expected page[size] to be kept, got %v", values)
+	}
+}
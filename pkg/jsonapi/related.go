@@ -1,5 +1,10 @@
 package jsonapi
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 type ResourceLinkage interface {
 	doNotExtend()
 }
@@ -10,6 +15,82 @@ type Relationship struct {
 	Meta  map[string]any  `json:"meta,omitempty" validate:"meta"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler for Relationship, decoding data into the matching
+// ResourceLinkage: NilResourceLinkage for null, ResourceLinkageCollection for an array, and
+// ResourceIdentifierLinkage for an object. Data is left nil when the member is absent.
+func (r *Relationship) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = Relationship{}
+	if links, ok := raw["links"]; ok {
+		if err := json.Unmarshal(links, &r.Links); err != nil {
+			return err
+		}
+	}
+	if meta, ok := raw["meta"]; ok {
+		if err := json.Unmarshal(meta, &r.Meta); err != nil {
+			return err
+		}
+	}
+
+	linkage, ok := raw["data"]
+	if !ok {
+		return nil
+	}
+	linkage = bytes.TrimSpace(linkage)
+	switch {
+	case bytes.Equal(linkage, []byte("null")):
+		r.Data = NilResourceLinkage{}
+	case len(linkage) > 0 && linkage[0] == '[':
+		var collection ResourceLinkageCollection
+		if err := json.Unmarshal(linkage, &collection); err != nil {
+			return err
+		}
+		r.Data = collection
+	default:
+		var identifier ResourceIdentifierLinkage
+		if err := json.Unmarshal(linkage, &identifier); err != nil {
+			return err
+		}
+		r.Data = identifier
+	}
+	return nil
+}
+
+// NullToOne returns a to-one relationship that is explicitly empty; it marshals with "data": null.
+func NullToOne() Relationship {
+	return Relationship{Data: NilResourceLinkage{}}
+}
+
+// IDs returns the ids in the relationship's linkage in order: one for a to-one relationship and one per
+// identifier for a to-many relationship. It returns nil for empty or absent linkage.
+// Identifiers that only have a lid are skipped.
+func (r Relationship) IDs() []string {
+	var ids []string
+	switch data := r.Data.(type) {
+	case ResourceIdentifierLinkage:
+		if data.ID != "" {
+			ids = append(ids, data.ID)
+		}
+	case ResourceLinkageCollection:
+		for _, identifier := range data {
+			if identifier.ID != "" {
+				ids = append(ids, identifier.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// UnloadedRelationship returns a relationship whose linkage is not included in the response.
+// It marshals with only the given links, omitting data.
+func UnloadedRelationship(links Links) Relationship {
+	return Relationship{Links: links}
+}
+
 type ResourceIdentifierLinkage struct {
 	Type string         `json:"type" validate:"type"`
 	ID   string         `json:"id,omitempty" validate:"id"`
@@ -39,3 +120,23 @@ type ResourceLinkageCollection []ResourceIdentifierLinkage
 
 // doNotExtend prevents external types from satisfying ResourceLinkage without the intended methods.
 func (ResourceLinkageCollection) doNotExtend() {}
+
+// Contains reports whether the collection has a resource identifier with the given type and id.
+func (c ResourceLinkageCollection) Contains(typeName, id string) bool {
+	for _, identifier := range c {
+		if identifier.Type == typeName && identifier.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// FindIncluded returns the included resource with the given type and id, for resolving relationship linkage.
+func FindIncluded(included []AnyDatum, typeName, id string) (*AnyDatum, bool) {
+	for i := range included {
+		if included[i].Type == typeName && included[i].ID == id {
+			return &included[i], true
+		}
+	}
+	return nil, false
+}
@@ -3,9 +3,11 @@ package jsonapi_test
 import (
 	"context"
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/errors"
 )
 
 // Requirements:
@@ -108,3 +110,171 @@ func TestNilResourceLinkage_UnmarshalJSON(t *testing.T) {
 		t.Errorf("Unexpected error unmarshaling other JSON: %v", err)
 	}
 }
+
+// Requirements:
+// - A resource identifier must have exactly one of id and lid.
+// - The rule applies to single linkage and to every element of a collection.
+func TestLinkageIdentity(t *testing.T) {
+	tests := []struct {
+		name    string
+		linkage map[string]any
+		code    errors.ErrorCode
+	}{
+		{"id only", map[string]any{"type": "tests", "id": "1"}, ""},
+		{"lid only", map[string]any{"type": "tests", "lid": "local-1"}, ""},
+		{"both", map[string]any{"type": "tests", "id": "1", "lid": "local-1"}, errors.CodeNotAllowed},
+		{"neither", map[string]any{"type": "tests"}, errors.CodeRequired},
+	}
+
+	for _, tt := range tests {
+		inputs := map[string]any{
+			"single":     tt.linkage,
+			"collection": []any{map[string]any{"type": "tests", "id": "2"}, tt.linkage},
+		}
+		for kind, input := range inputs {
+			t.Run(tt.name+"/"+kind, func(t *testing.T) {
+				_, errs := jsonapi.ResourceLinkageRuleSet.Apply(context.Background(), input)
+				if tt.code == "" {
+					if errs != nil {
+						t.Errorf("Expected errors to be nil, got: %s", errs)
+					}
+					return
+				}
+				if errs == nil {
+					t.Fatal("Expected errors to not be nil")
+				}
+				unwrapped := errors.Unwrap(errs)
+				if len(unwrapped) != 1 {
+					t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+				}
+				if code := unwrapped[0].(errors.ValidationError).Code(); code != tt.code {
+					t.Errorf("Expected code %s, got: %s", tt.code, code)
+				}
+			})
+		}
+	}
+}
+
+// Requirements:
+// - NullToOne marshals with "data": null.
+// - A NilResourceLinkage value marshals as "data": null even though it is a struct.
+// - A relationship with nil Data omits data.
+// - Links-only relationships, including UnloadedRelationship, marshal without data.
+// - Each shape decodes back to the same data.
+func TestRelationshipOutputShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		rel  jsonapi.Relationship
+		want string
+	}{
+		{"null to-one", jsonapi.NullToOne(), `{"data":null}`},
+		{"nil linkage", jsonapi.Relationship{Data: jsonapi.NilResourceLinkage{}}, `{"data":null}`},
+		{"absent data", jsonapi.Relationship{}, `{}`},
+		{"unloaded", jsonapi.UnloadedRelationship(jsonapi.Links{"related": jsonapi.StringLink("/articles/1/author")}), `{"links":{"related":"/articles/1/author"}}`},
+		{"links only", jsonapi.Relationship{Links: jsonapi.Links{"self": jsonapi.StringLink("/articles/1/relationships/author")}}, `{"links":{"self":"/articles/1/relationships/author"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.rel)
+			if err != nil {
+				t.Fatalf("Expected marshal error to be nil, got: %s", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Expected %s, got: %s", tt.want, data)
+			}
+
+			var decoded jsonapi.Relationship
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Expected unmarshal error to be nil, got: %s", err)
+			}
+			_, isNil := decoded.Data.(jsonapi.NilResourceLinkage)
+			_, wantNil := tt.rel.Data.(jsonapi.NilResourceLinkage)
+			if isNil != wantNil || (decoded.Data == nil) != (tt.rel.Data == nil) {
+				t.Errorf("Expected data %#v after round trip, got: %#v", tt.rel.Data, decoded.Data)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - A resource identifier with only a lid marshals type and lid and omits id.
+// - Collection elements with only a lid do the same, alongside elements with an id.
+// - A relationship with lid linkage marshals the lid under data.
+func TestResourceIdentifierLinkage_MarshalLid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"single", jsonapi.ResourceIdentifierLinkage{Type: "x", LID: "l1"}, `{"type":"x","lid":"l1"}`},
+		{"collection", jsonapi.ResourceLinkageCollection{{Type: "x", LID: "l1"}, {Type: "x", ID: "2"}}, `[{"type":"x","lid":"l1"},{"type":"x","id":"2"}]`},
+		{"relationship", jsonapi.Relationship{Data: jsonapi.ResourceIdentifierLinkage{Type: "x", LID: "l1"}}, `{"data":{"type":"x","lid":"l1"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Expected marshal error to be nil, got: %s", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Expected %s, got: %s", tt.want, data)
+			}
+		})
+	}
+}
+
+// Requirements:
+// - IDs returns the id of a to-one relationship and the ids of a to-many relationship in order.
+// - IDs is nil for null and absent linkage.
+// - Contains matches on both type and id.
+// - FindIncluded returns the included resource for a linkage, or false if it is absent.
+func TestRelationshipLookup(t *testing.T) {
+	var article struct {
+		Relationships map[string]jsonapi.Relationship `json:"relationships"`
+	}
+	err := json.Unmarshal([]byte(`{"relationships": {
+		"author": {"data": {"type": "people", "id": "9"}},
+		"comments": {"data": [{"type": "comments", "id": "5"}, {"type": "comments", "id": "12"}]},
+		"editor": {"data": null},
+		"tags": {"links": {"related": "/articles/1/tags"}}
+	}}`), &article)
+	if err != nil {
+		t.Fatalf("Expected unmarshal error to be nil, got: %s", err)
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"author", []string{"9"}},
+		{"comments", []string{"5", "12"}},
+		{"editor", nil},
+		{"tags", nil},
+	}
+	for _, tt := range tests {
+		if got := article.Relationships[tt.name].IDs(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected ids %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	comments := article.Relationships["comments"].Data.(jsonapi.ResourceLinkageCollection)
+	if !comments.Contains("comments", "12") {
+		t.Error("Expected comments 12 to be contained")
+	}
+	if comments.Contains("people", "12") || comments.Contains("comments", "9") {
+		t.Error("Expected a mismatched type or id not to be contained")
+	}
+
+	included := []jsonapi.AnyDatum{
+		{Type: "people", ID: "9", Attributes: map[string]any{"name": "Dan"}},
+		{Type: "comments", ID: "5", Attributes: map[string]any{"body": "First"}},
+	}
+	author := article.Relationships["author"].Data.(jsonapi.ResourceIdentifierLinkage)
+	found, ok := jsonapi.FindIncluded(included, author.Type, author.ID)
+	if !ok || found.Attributes["name"] != "Dan" {
+		t.Errorf("Expected to find the author, got %+v", found)
+	}
+	if _, ok := jsonapi.FindIncluded(included, "comments", "12"); ok {
+		t.Error("Expected comments 12 not to be found")
+	}
+}
@@ -0,0 +1,157 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// RelationshipDocumentRuleSet validates the body of a relationship endpoint request
+// (e.g. PATCH /articles/1/relationships/comments), whose primary data is resource linkage.
+type RelationshipDocumentRuleSet struct {
+	allowedType string
+	required    bool
+	errorConfig *errors.ErrorConfig
+	rules.NoConflict[Relationship]
+}
+
+// NewRelationshipRuleSet returns a rule set for a relationship endpoint document. The data member must be
+// present and hold null, a resource identifier, or an array of resource identifiers of allowedType.
+// An empty allowedType accepts any type.
+func NewRelationshipRuleSet(allowedType string) *RelationshipDocumentRuleSet {
+	return &RelationshipDocumentRuleSet{allowedType: allowedType}
+}
+
+// clone returns a shallow copy of the rule set for use in builder methods.
+func (ruleSet *RelationshipDocumentRuleSet) clone() *RelationshipDocumentRuleSet {
+	return &RelationshipDocumentRuleSet{
+		allowedType: ruleSet.allowedType,
+		required:    ruleSet.required,
+		errorConfig: ruleSet.errorConfig,
+	}
+}
+
+// WithRequired marks the document as required.
+func (ruleSet *RelationshipDocumentRuleSet) WithRequired() *RelationshipDocumentRuleSet {
+	if ruleSet.required {
+		return ruleSet
+	}
+
+	newRuleSet := ruleSet.clone()
+	newRuleSet.required = true
+	return newRuleSet
+}
+
+// Required reports whether the document is required.
+func (ruleSet *RelationshipDocumentRuleSet) Required() bool {
+	return ruleSet.required
+}
+
+// WithDocsURI adds a documentation link to errors.
+func (ruleSet *RelationshipDocumentRuleSet) WithDocsURI(uri string) *RelationshipDocumentRuleSet {
+	newRuleSet := ruleSet.clone()
+	if newRuleSet.errorConfig == nil {
+		newRuleSet.errorConfig = &errors.ErrorConfig{}
+	}
+	newRuleSet.errorConfig = newRuleSet.errorConfig.WithDocsURI(uri)
+	return newRuleSet
+}
+
+// Apply decodes and validates the input (string or map) into a Relationship.
+func (ruleSet *RelationshipDocumentRuleSet) Apply(ctx context.Context, input any) (Relationship, errors.ValidationError) {
+	var zero Relationship
+	if isOptions(ctx) {
+		return zero, nil
+	}
+	if ruleSet.errorConfig != nil {
+		ctx = errors.WithErrorConfig(ctx, ruleSet.errorConfig)
+	}
+
+	if inputStr, ok := input.(string); ok {
+		var decodedInput any
+		if err := json.Unmarshal([]byte(inputStr), &decodedInput); err != nil {
+			return zero, errors.Join(&jsonAPIErrorWrapper{err: MalformedJSONError([]byte(inputStr))})
+		}
+		input = decodedInput
+	}
+
+	if inputMap, ok := input.(map[string]any); ok {
+		if _, ok := inputMap["data"]; !ok {
+			dataCtx := rulecontext.WithPathString(ctx, "data")
+			return zero, ToJSONAPIErrors(errors.Errorf(errors.CodeRequired, dataCtx, "data required", "Relationship document must contain resource linkage in data"), SourcePointer)
+		}
+	}
+
+	rel, errs := RelationshipRuleSet.Apply(ctx, input)
+	if errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+	if errs := evaluateRelationshipMethod(ctx, rel.Data); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+	if errs := ruleSet.evaluateType(ctx, rel.Data); errs != nil {
+		return zero, ToJSONAPIErrors(errs, SourcePointer)
+	}
+	return rel, nil
+}
+
+// evaluateRelationshipMethod checks the linkage against the request method. A DELETE removes members
+// from a to-many relationship, so its data must be an array; a to-one relationship cannot be cleared this way.
+func evaluateRelationshipMethod(ctx context.Context, linkage ResourceLinkage) errors.ValidationError {
+	if MethodFromContext(ctx) != "DELETE" {
+		return nil
+	}
+	if _, ok := linkage.(ResourceLinkageCollection); ok {
+		return nil
+	}
+	dataCtx := rulecontext.WithPathString(ctx, "data")
+	return errors.Errorf(errors.CodeForbidden, dataCtx, "to-many linkage required", "DELETE may only remove members of a to-many relationship; data must be an array")
+}
+
+// evaluateType checks that every resource identifier in linkage has the allowed type.
+func (ruleSet *RelationshipDocumentRuleSet) evaluateType(ctx context.Context, linkage ResourceLinkage) errors.ValidationError {
+	if ruleSet.allowedType == "" {
+		return nil
+	}
+
+	dataCtx := rulecontext.WithPathString(ctx, "data")
+	switch data := linkage.(type) {
+	case ResourceIdentifierLinkage:
+		return evaluateLinkageType(dataCtx, data, ruleSet.allowedType)
+	case ResourceLinkageCollection:
+		var allErrors []error
+		for i, identifier := range data {
+			itemCtx := rulecontext.WithPathString(dataCtx, strconv.Itoa(i))
+			if err := evaluateLinkageType(itemCtx, identifier, ruleSet.allowedType); err != nil {
+				allErrors = append(allErrors, err)
+			}
+		}
+		return errors.Join(allErrors...)
+	}
+	return nil
+}
+
+// Evaluate validates a Relationship value and returns any validation errors.
+func (ruleSet *RelationshipDocumentRuleSet) Evaluate(ctx context.Context, value Relationship) errors.ValidationError {
+	if errs := evaluateRelationshipMethod(ctx, value.Data); errs != nil {
+		return ToJSONAPIErrors(errs, SourcePointer)
+	}
+	if errs := ruleSet.evaluateType(ctx, value.Data); errs != nil {
+		return ToJSONAPIErrors(errs, SourcePointer)
+	}
+	return nil
+}
+
+// Any returns the rule set as rules.RuleSet[any] for use with generic validators.
+func (ruleSet *RelationshipDocumentRuleSet) Any() rules.RuleSet[any] {
+	return rules.WrapAny[Relationship](ruleSet)
+}
+
+// String returns a stable name for the rule set for error messages and debugging.
+func (ruleSet *RelationshipDocumentRuleSet) String() string {
+	return "RelationshipDocumentRuleSet"
+}
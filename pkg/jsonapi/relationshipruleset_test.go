@@ -0,0 +1,110 @@
+package jsonapi_test
+
+import (
+	"context"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/errors"
+)
+
+// Requirements:
+// - To-one, to-many, and null linkage are decoded into Relationship.Data.
+// - Attributes are not required.
+func TestRelationshipRuleSet_Valid(t *testing.T) {
+	ruleSet := jsonapi.NewRelationshipRuleSet("comments")
+	ctx := context.Background()
+
+	rel, errs := ruleSet.Apply(ctx, `{"data": {"type": "comments", "id": "1"}}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if linkage, ok := rel.Data.(jsonapi.ResourceIdentifierLinkage); !ok || linkage.ID != "1" {
+		t.Errorf("Expected to-one linkage, got: %#v", rel.Data)
+	}
+
+	rel, errs = ruleSet.Apply(ctx, `{"data": [{"type": "comments", "id": "1"}, {"type": "comments", "id": "2"}]}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if collection, ok := rel.Data.(jsonapi.ResourceLinkageCollection); !ok || len(collection) != 2 {
+		t.Errorf("Expected to-many linkage, got: %#v", rel.Data)
+	}
+
+	rel, errs = ruleSet.Apply(ctx, `{"data": null}`)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %s", errs)
+	}
+	if _, ok := rel.Data.(jsonapi.NilResourceLinkage); !ok {
+		t.Errorf("Expected null linkage, got: %#v", rel.Data)
+	}
+}
+
+// Requirements:
+// - A missing data member errors with CodeRequired at /data.
+// - Linkage of the wrong type errors with CodeNotAllowed at its type pointer.
+// - Identifiers without id or lid error under /data.
+func TestRelationshipRuleSet_Invalid(t *testing.T) {
+	ruleSet := jsonapi.NewRelationshipRuleSet("comments")
+	ctx := context.Background()
+
+	tests := []struct {
+		input   string
+		code    errors.ErrorCode
+		pointer string
+	}{
+		{`{"meta": {}}`, errors.CodeRequired, "/data"},
+		{`{"data": {"type": "people", "id": "9"}}`, errors.CodeNotAllowed, "/data/type"},
+		{`{"data": [{"type": "comments", "id": "1"}, {"type": "people", "id": "9"}]}`, errors.CodeNotAllowed, "/data/1/type"},
+		{`{"data": {"type": "comments"}}`, errors.CodeRequired, "/data/id"},
+	}
+	for _, tt := range tests {
+		_, errs := ruleSet.Apply(ctx, tt.input)
+		if errs == nil {
+			t.Errorf("Expected an error for %s", tt.input)
+			continue
+		}
+		list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+		if len(list) != 1 {
+			t.Errorf("%s: expected 1 error, got %d", tt.input, len(list))
+			continue
+		}
+		if list[0].Code != string(tt.code) {
+			t.Errorf("%s: expected code %s, got %s", tt.input, tt.code, list[0].Code)
+		}
+		if list[0].Source == nil || list[0].Source.Pointer != tt.pointer {
+			t.Errorf("%s: expected pointer %s, got %+v", tt.input, tt.pointer, list[0].Source)
+		}
+	}
+}
+
+// Requirements:
+// - DELETE with an array of identifiers passes.
+// - DELETE with a single identifier or null errors with CodeForbidden at /data.
+func TestRelationshipRuleSet_Delete(t *testing.T) {
+	ruleSet := jsonapi.NewRelationshipRuleSet("comments")
+	ctx := jsonapi.WithMethod(context.Background(), "DELETE")
+
+	if _, errs := ruleSet.Apply(ctx, `{"data": [{"type": "comments", "id": "1"}, {"type": "comments", "id": "2"}]}`); errs != nil {
+		t.Errorf("Expected errors to be nil, got: %s", errs)
+	}
+
+	for _, input := range []string{`{"data": {"type": "comments", "id": "1"}}`, `{"data": null}`} {
+		_, errs := ruleSet.Apply(ctx, input)
+		if errs == nil {
+			t.Errorf("Expected an error for %s", input)
+			continue
+		}
+		list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+		if len(list) != 1 {
+			t.Errorf("%s: expected 1 error, got %d", input, len(list))
+			continue
+		}
+		if list[0].Code != string(errors.CodeForbidden) {
+			t.Errorf("%s: expected code %s, got %s", input, errors.CodeForbidden, list[0].Code)
+		}
+		if list[0].Source == nil || list[0].Source.Pointer != "/data" {
+			t.Errorf("%s: expected pointer /data, got %+v", input, list[0].Source)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"proto.zip/studio/validate/pkg/errors"
+)
+
+// RequestContext returns a context derived from the request with the HTTP method, the extensions
+// negotiated in the Content-Type ext parameter and, when the route declares an "id" path wildcard,
+// the resource ID set for use by validators.
+func RequestContext(r *http.Request) context.Context {
+	return requestContext(r, nil)
+}
+
+// requestContext is RequestContext with the extensions in known used to resolve the prefixes of the
+// negotiated extensions (see httpHeaderToHeader).
+func requestContext(r *http.Request, known []Extension) context.Context {
+	ctx := WithMethod(r.Context(), r.Method)
+	if id := r.PathValue("id"); id != "" {
+		ctx = WithId(ctx, id)
+	}
+	if exts := httpHeaderToHeader(r.Header, known).Ext; len(exts) > 0 {
+		ctx = WithExtensions(ctx, exts...)
+	}
+	return ctx
+}
+
+// DecodeRequest validates the request headers and decodes the request body with the given rule set.
+// The method and resource ID are set on the validation context from the request (see RequestContext).
+// On failure it returns JSON:API errors that are ready to be serialized in an ErrorResponse.
+func DecodeRequest[T any](r *http.Request, rs *SingleRuleSet[T]) (*SingleDatumEnvelope[T], []Error) {
+	ctx := requestContext(r, rs.negotiated)
+
+	if _, errs := Headers().Apply(ctx, r.Header); errs != nil {
+		return nil, ErrorsFromValidationError(errs, SourceHeader)
+	}
+
+	if r.Body == nil {
+		return nil, []Error{{Status: "400", Title: "Request body required", Detail: "Request body must contain a JSON:API document"}}
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, []Error{{Status: "400", Title: "Invalid request body", Detail: err.Error()}}
+	}
+
+	envelope, errs := rs.Apply(ctx, string(body))
+	if errs != nil {
+		return nil, ErrorsFromValidationError(errs, SourcePointer)
+	}
+	return &envelope, nil
+}
+
+// ValidateAndBind validates input with rs and decodes the attributes of the primary resource into dst,
+// matching members to the json tags of M. Errors from either step are returned as JSON:API errors;
+// an attribute that does not fit its field in M is reported at its pointer under /data/attributes.
+func ValidateAndBind[T, M any](ctx context.Context, rs *SingleRuleSet[T], input any, dst *M) []Error {
+	envelope, errs := rs.Apply(ctx, input)
+	if errs != nil {
+		return ErrorsFromValidationError(errs, SourcePointer)
+	}
+
+	raw, err := json.Marshal(envelope.Data.Attributes)
+	if err != nil {
+		return []Error{{Status: "500", Title: "Binding failed", Detail: err.Error()}}
+	}
+	if err := json.Unmarshal(raw, dst); err != nil {
+		pointer := "/data/attributes"
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok && typeErr.Field != "" {
+			for _, segment := range strings.Split(typeErr.Field, ".") {
+				pointer += "/" + jsonPointerEscaper.Replace(segment)
+			}
+		}
+		return []Error{{
+			Status: "422",
+			Code:   string(errors.CodeType),
+			Title:  "Invalid attribute",
+			Detail: err.Error(),
+			Source: &Source{Pointer: pointer},
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package jsonapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/rules"
+)
+
+// Requirements:
+// - Method and path id are set on the context.
+// - Missing id wildcard leaves the id unset.
+func TestRequestContext(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPatch, "/articles/1", nil)
+	r.SetPathValue("id", "1")
+
+	ctx := jsonapi.RequestContext(r)
+	if method := jsonapi.MethodFromContext(ctx); method != http.MethodPatch {
+		t.Errorf("Expected method to be %q, got %q", http.MethodPatch, method)
+	}
+	if id := jsonapi.IdFromContext(ctx); id != "1" {
+		t.Errorf("Expected id to be %q, got %q", "1", id)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/articles", nil)
+	ctx = jsonapi.RequestContext(r)
+	if id := jsonapi.IdFromContext(ctx); id != "" {
+		t.Errorf("Expected id to be empty, got %q", id)
+	}
+}
+
+// Requirements:
+// - Returns the decoded envelope for a valid request.
+// - Returns header errors when Content-Type is wrong.
+// - Returns body errors with source.pointer when the document is invalid.
+func TestDecodeRequest(t *testing.T) {
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", jsonapi.Attributes().WithKey("title", rules.String().WithMinLen(3).Any()))
+
+	newRequest := func(body, contentType string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		return r
+	}
+
+	env, errs := jsonapi.DecodeRequest(newRequest(`{"data":{"type":"articles","attributes":{"title":"Hello"}}}`, jsonapi.MediaTypeJSONAPI), ruleSet)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %v", errs)
+	}
+	if env.Data.Attributes["title"] != "Hello" {
+		t.Errorf("Expected title to be %q, got %v", "Hello", env.Data.Attributes["title"])
+	}
+
+	_, errs = jsonapi.DecodeRequest(newRequest(`{"data":{"type":"articles","attributes":{"title":"Hello"}}}`, "application/json"), ruleSet)
+	if len(errs) == 0 {
+		t.Fatal("Expected errors for wrong Content-Type")
+	}
+	if errs[0].Source == nil || errs[0].Source.Header != "Content-Type" {
+		t.Errorf("Expected source.header to be Content-Type, got %+v", errs[0].Source)
+	}
+
+	_, errs = jsonapi.DecodeRequest(newRequest(`{"data":{"type":"articles","attributes":{"title":"Hi"}}}`, jsonapi.MediaTypeJSONAPI), ruleSet)
+	if len(errs) == 0 {
+		t.Fatal("Expected errors for invalid attributes")
+	}
+	if errs[0].Source == nil || !strings.HasSuffix(errs[0].Source.Pointer, "/attributes/title") {
+		t.Errorf("Expected source.pointer to reference the title attribute, got %+v", errs[0].Source)
+	}
+}
+
+// Requirements:
+// - A valid store create body is validated and its attributes are bound into the domain struct by json tag.
+// - Validation errors are returned as JSON:API errors and dst is left untouched.
+// - An attribute that does not fit the domain struct is reported at its pointer.
+func TestValidateAndBind(t *testing.T) {
+	type Store struct {
+		Name     string `json:"name"`
+		Address  string `json:"address"`
+		Capacity int    `json:"capacity"`
+	}
+	ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("stores", jsonapi.Attributes().
+		WithKey("name", rules.String().WithMinLen(1).Any()).
+		WithKey("address", rules.String().Any()).
+		WithKey("capacity", rules.String().Any()))
+	ctx := jsonapi.WithMethod(context.Background(), http.MethodPost)
+
+	var store Store
+	errs := jsonapi.ValidateAndBind(ctx, ruleSet, `{"data":{"type":"stores","attributes":{"name":"Main St","address":"1 Main St"}}}`, &store)
+	if errs != nil {
+		t.Fatalf("Expected errors to be nil, got: %v", errs)
+	}
+	if store != (Store{Name: "Main St", Address: "1 Main St"}) {
+		t.Errorf("Expected the store to be bound, got %+v", store)
+	}
+
+	var invalid Store
+	errs = jsonapi.ValidateAndBind(ctx, ruleSet, `{"data":{"type":"stores","attributes":{"name":""}}}`, &invalid)
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got: %v", errs)
+	}
+	if errs[0].Source == nil || errs[0].Source.Pointer != "/data/attributes/name" {
+		t.Errorf("Expected source.pointer /data/attributes/name, got %+v", errs[0].Source)
+	}
+	if invalid != (Store{}) {
+		t.Errorf("Expected dst to be untouched, got %+v", invalid)
+	}
+
+	errs = jsonapi.ValidateAndBind(ctx, ruleSet, `{"data":{"type":"stores","attributes":{"name":"Main St","capacity":"large"}}}`, &invalid)
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got: %v", errs)
+	}
+	if errs[0].Status != "422" || errs[0].Source == nil || errs[0].Source.Pointer != "/data/attributes/capacity" {
+		t.Errorf("Expected a 422 at /data/attributes/capacity, got %+v", errs[0])
+	}
+}
@@ -0,0 +1,78 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"proto.zip/studio/validate/pkg/errors"
+)
+
+// marshalErrorBody is written when a response payload cannot be serialized.
+const marshalErrorBody = `{"errors":[{"status":"500","title":"Marshal error"}]}`
+
+// WriteResponse serializes payload and writes it with the given status and the JSON:API Content-Type.
+// A 204 No Content status or a nil payload writes no body.
+func WriteResponse(w http.ResponseWriter, status int, payload any) {
+	if status == http.StatusNoContent || payload == nil {
+		w.WriteHeader(status)
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body = []byte(marshalErrorBody)
+	}
+
+	w.Header().Set("Content-Type", MediaTypeJSONAPI)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// WriteErrors writes errs as an ErrorResponse using the status derived by ErrorsStatus.
+func WriteErrors(w http.ResponseWriter, errs []Error) {
+	WriteResponse(w, ErrorsStatus(errs), ErrorResponse{Errors: errs})
+}
+
+// RespondValidationError converts errs to JSON:API errors with the source kind (SourcePointer for bodies,
+// SourceParameter for query strings, SourceHeader for headers) and writes them as with WriteErrors.
+func RespondValidationError(w http.ResponseWriter, errs errors.ValidationError, kind ErrorSourceKind) {
+	WriteErrors(w, ErrorsFromValidationError(errs, kind))
+}
+
+// ErrorsStatus returns the HTTP status code that best represents errs.
+// If every error with a status shares the same one it is returned; otherwise the most generally
+// applicable code is used (400 for client errors only, 500 when any server error is present).
+// Errors without a parsable status are ignored; if none remain, 500 is returned.
+func ErrorsStatus(errs []Error) int {
+	status := 0
+	mixed := false
+	serverError := false
+	for _, e := range errs {
+		code, err := strconv.Atoi(e.Status)
+		if err != nil || code < 100 {
+			continue
+		}
+		if code >= 500 {
+			serverError = true
+		}
+		if status == 0 {
+			status = code
+		} else if status != code {
+			mixed = true
+		}
+	}
+
+	switch {
+	case status == 0:
+		return http.StatusInternalServerError
+	case !mixed:
+		return status
+	case serverError:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,135 @@
+package jsonapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
+)
+
+// Requirements:
+// - Writes the status, Content-Type, and serialized payload.
+// - 204 No Content writes no body.
+func TestWriteResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	jsonapi.WriteResponse(rec, http.StatusCreated, jsonapi.SingleDatumEnvelope[map[string]any]{
+		Data: jsonapi.Datum[map[string]any]{ID: "1", Type: "articles", Attributes: map[string]any{"title": "Hello"}},
+	})
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Expected status %d, got %d", http.StatusCreated, rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != jsonapi.MediaTypeJSONAPI {
+		t.Errorf("Expected Content-Type %q, got %q", jsonapi.MediaTypeJSONAPI, ct)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected JSON body, got error: %v", err)
+	}
+	if _, ok := decoded["data"]; !ok {
+		t.Errorf("Expected data member in body, got %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	jsonapi.WriteResponse(rec, http.StatusNoContent, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("Expected empty body for 204, got %q", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "" {
+		t.Errorf("Expected no Content-Type for 204, got %q", ct)
+	}
+}
+
+// Requirements:
+// - Writes an ErrorResponse with the status derived from the errors.
+func TestWriteErrors(t *testing.T) {
+	rec := httptest.NewRecorder()
+	jsonapi.WriteErrors(rec, []jsonapi.Error{{Status: "404", Title: "Not Found"}})
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != jsonapi.MediaTypeJSONAPI {
+		t.Errorf("Expected Content-Type %q, got %q", jsonapi.MediaTypeJSONAPI, ct)
+	}
+	var decoded jsonapi.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected JSON body, got error: %v", err)
+	}
+	if len(decoded.Errors) != 1 || decoded.Errors[0].Title != "Not Found" {
+		t.Errorf("Unexpected errors in body: %+v", decoded.Errors)
+	}
+}
+
+// Requirements:
+// - Query string validation errors are written with status 400 and source.parameter.
+// - Body validation errors are written with status 422 and source.pointer.
+func TestRespondValidationError(t *testing.T) {
+	queryCtx := rulecontext.WithPathString(context.Background(), "query[sort]")
+	rec := httptest.NewRecorder()
+	jsonapi.RespondValidationError(rec, errors.Errorf(errors.CodeNotAllowed, queryCtx, "not allowed", "Sort is not allowed"), jsonapi.SourceParameter)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != jsonapi.MediaTypeJSONAPI {
+		t.Errorf("Expected Content-Type %q, got %q", jsonapi.MediaTypeJSONAPI, ct)
+	}
+	var decoded jsonapi.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected JSON body, got error: %v", err)
+	}
+	if len(decoded.Errors) != 1 || decoded.Errors[0].Source == nil || decoded.Errors[0].Source.Parameter != "sort" {
+		t.Errorf("Unexpected errors in body: %+v", decoded.Errors)
+	}
+
+	bodyCtx := rulecontext.WithPathString(context.Background(), "data")
+	rec = httptest.NewRecorder()
+	jsonapi.RespondValidationError(rec, errors.Errorf(errors.CodeRequired, bodyCtx, "required", "data is required"), jsonapi.SourcePointer)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+	decoded = jsonapi.ErrorResponse{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected JSON body, got error: %v", err)
+	}
+	if len(decoded.Errors) != 1 || decoded.Errors[0].Source == nil || decoded.Errors[0].Source.Pointer != "/data" {
+		t.Errorf("Unexpected errors in body: %+v", decoded.Errors)
+	}
+}
+
+func TestErrorsStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		expected int
+	}{
+		{"single", []string{"422"}, 422},
+		{"same", []string{"422", "422"}, 422},
+		{"mixed client", []string{"400", "422"}, 400},
+		{"mixed server", []string{"422", "503"}, 500},
+		{"ignores empty", []string{"", "409"}, 409},
+		{"none", nil, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := make([]jsonapi.Error, len(tt.statuses))
+			for i, s := range tt.statuses {
+				errs[i].Status = s
+			}
+			if got := jsonapi.ErrorsStatus(errs); got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
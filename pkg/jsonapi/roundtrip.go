@@ -0,0 +1,32 @@
+package jsonapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// RoundTrip decodes raw into the envelope matching its primary data (DatumCollectionEnvelope[T] when data is
+// an array, SingleDatumEnvelope[T] otherwise) and encodes it again. It is meant for tests that check the
+// package's types preserve every member of a document; the output should equal raw up to key order.
+func RoundTrip[T any](raw []byte) ([]byte, error) {
+	var probe struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return nil, err
+	}
+
+	if data := bytes.TrimSpace(probe.Data); len(data) > 0 && data[0] == '[' {
+		var envelope DatumCollectionEnvelope[T]
+		if err := json.Unmarshal(raw, &envelope); err != nil {
+			return nil, err
+		}
+		return json.Marshal(envelope)
+	}
+
+	var envelope SingleDatumEnvelope[T]
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope)
+}
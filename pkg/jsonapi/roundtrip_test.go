@@ -0,0 +1,62 @@
+package jsonapi_test
+
+import (
+	"testing"
+
+	"proto.zip/studio/jsonapi/pkg/jsonapi"
+)
+
+// Requirements:
+// - A rich single resource document round-trips with every member preserved.
+// - A collection document round-trips, including lid-only resources and empty attributes.
+// - Invalid JSON returns an error.
+func TestRoundTrip(t *testing.T) {
+	docs := map[string]string{
+		"single": `{
+			"jsonapi": {"version": "1.1", "ext": ["https://example.com/ext/version"]},
+			"version:id": "v1",
+			"@context": "https://example.com/context",
+			"links": {"self": "/articles/1", "describedby": {"href": "/schema", "title": "Schema"}},
+			"meta": {"requestId": "r1"},
+			"data": {
+				"type": "articles", "id": "1",
+				"attributes": {"title": "Hello", "tags": ["a", "b"]},
+				"relationships": {
+					"author": {"data": {"type": "people", "id": "9"}, "links": {"related": "/articles/1/author"}},
+					"comments": {"data": [{"type": "comments", "id": "5", "meta": {"n": 1}}, {"type": "comments", "lid": "c2"}]},
+					"editor": {"data": null},
+					"tags": {"links": {"self": "/articles/1/relationships/tags"}, "meta": {"count": 2}}
+				},
+				"links": {"self": "/articles/1", "prev": null},
+				"meta": {"views": 10},
+				"version:etag": "abc",
+				"@type": "Article"
+			},
+			"included": [{"type": "people", "id": "9", "attributes": {"name": "Dan"}}]
+		}`,
+		"collection": `{
+			"data": [
+				{"type": "articles", "id": "1", "attributes": {}},
+				{"type": "articles", "lid": "local-1", "attributes": {"title": "Draft"}}
+			],
+			"meta": {"total": 2},
+			"version:id": "v1"
+		}`,
+	}
+
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			out, err := jsonapi.RoundTrip[map[string]any]([]byte(doc))
+			if err != nil {
+				t.Fatalf("Unexpected error during round trip: %v", err)
+			}
+			if !jsonEqual(doc, string(out)) {
+				t.Errorf("Expected JSON: %s\nGot JSON: %s", doc, out)
+			}
+		})
+	}
+
+	if _, err := jsonapi.RoundTrip[map[string]any]([]byte(`{"data": [`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
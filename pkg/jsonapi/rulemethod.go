@@ -68,3 +68,36 @@ func IndexRule[T any, TK comparable]() rules.Rule[T] {
 		return nil
 	})
 }
+
+// isOptions reports whether the request in the context is an OPTIONS (e.g. CORS preflight) request.
+// Such requests carry no JSON:API payload, so body and query rule sets skip validation for them.
+func isOptions(ctx context.Context) bool {
+	return MethodFromContext(ctx) == "OPTIONS"
+}
+
+// isIndexGET reports whether the request in the context is an index GET or HEAD request.
+// A context without a method is treated as an index GET request.
+func isIndexGET(ctx context.Context) bool {
+	method := MethodFromContext(ctx)
+	if method == "" {
+		return true
+	}
+	return IdFromContext(ctx) == "" && (method == "GET" || method == "HEAD")
+}
+
+// RequireIndexGET returns an error unless the request in the context is an index GET or HEAD request.
+// This is the check used by the standard sort parameter; a context without a method always passes.
+func RequireIndexGET(ctx context.Context) errors.ValidationError {
+	if isIndexGET(ctx) {
+		return nil
+	}
+	return errors.Errorf(errors.CodeForbidden, ctx, "Index GET request required", "Value is only allowed on index GET requests")
+}
+
+// IndexGETRule creates a new Rule that applies RequireIndexGET, for use with custom query parameters
+// registered with QueryRuleSet.WithParam.
+func IndexGETRule[T any]() rules.Rule[T] {
+	return rules.RuleFunc[T](func(ctx context.Context, value T) errors.ValidationError {
+		return RequireIndexGET(ctx)
+	})
+}
@@ -2,9 +2,11 @@ package jsonapi_test
 
 import (
 	"context"
+	"net/url"
 	"testing"
 
 	"proto.zip/studio/jsonapi/pkg/jsonapi"
+	"proto.zip/studio/validate/pkg/rules"
 )
 
 func TestHTTPMethodRule_String(t *testing.T) {
@@ -67,3 +69,46 @@ func TestHTTPMethodRule_Conflict(t *testing.T) {
 	}
 }
 
+// Requirements:
+// - RequireIndexGET passes without a method and on index GET/HEAD requests.
+// - RequireIndexGET errors on other methods and on individual resource requests.
+// - IndexGETRule applies the same check to a custom query parameter.
+func TestRequireIndexGET(t *testing.T) {
+	tests := []struct {
+		method string
+		id     string
+		ok     bool
+	}{
+		{"", "", true},
+		{"GET", "", true},
+		{"HEAD", "", true},
+		{"GET", "1", false},
+		{"POST", "", false},
+		{"PATCH", "1", false},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.method != "" {
+			ctx = jsonapi.WithMethod(ctx, tt.method)
+		}
+		if tt.id != "" {
+			ctx = jsonapi.WithId(ctx, tt.id)
+		}
+		err := jsonapi.RequireIndexGET(ctx)
+		if tt.ok && err != nil {
+			t.Errorf("Expected %s %q to pass, got: %s", tt.method, tt.id, err)
+		} else if !tt.ok && err == nil {
+			t.Errorf("Expected %s %q to be rejected", tt.method, tt.id)
+		}
+	}
+
+	ruleSet := jsonapi.Query().WithParam("q[Search]", rules.String().WithRule(jsonapi.IndexGETRule[string]()).Any())
+	values := url.Values{"q[Search]": []string{"hello"}}
+
+	if _, errs := ruleSet.Apply(jsonapi.WithMethod(context.Background(), "GET"), values); errs != nil {
+		t.Errorf("Expected index GET to pass, got: %s", errs)
+	}
+	if _, errs := ruleSet.Apply(jsonapi.WithId(jsonapi.WithMethod(context.Background(), "GET"), "1"), values); errs == nil {
+		t.Error("Expected individual resource GET to be rejected")
+	}
+}
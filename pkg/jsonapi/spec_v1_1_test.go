@@ -354,10 +354,12 @@ func TestSpecV1_1_JSONAPIObject(t *testing.T) {
 		}
 	}`
 
-	_, errs := ruleSet.Apply(ctx, docWithJsonAPI)
+	out, errs := ruleSet.Apply(ctx, docWithJsonAPI)
 	// jsonapi object is a top-level member
 	if errs != nil {
 		t.Errorf("Document with jsonapi object should be valid: %s", errs)
+	} else if out.JSONAPI == nil || out.JSONAPI.Version != jsonapi.Version_1_0 {
+		t.Errorf("Expected jsonapi version 1.0 to be decoded, got %+v", out.JSONAPI)
 	}
 }
 
@@ -572,9 +574,15 @@ func TestSpecV1_1_CreatingResources(t *testing.T) {
 		}
 	}`
 
+	// Servers that do not support client-generated ids MUST respond with 403 Forbidden
 	_, errs = ruleSet.Apply(ctx, createWithId)
+	if errs == nil {
+		t.Errorf("Creating resource with client-generated id should be rejected by default")
+	}
+
+	_, errs = ruleSet.WithClientGeneratedID(true).Apply(ctx, createWithId)
 	if errs != nil {
-		t.Errorf("Creating resource with client-generated id should be valid: %s", errs)
+		t.Errorf("Creating resource with client-generated id should be valid when allowed: %s", errs)
 	}
 }
 
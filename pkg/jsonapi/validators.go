@@ -2,8 +2,13 @@ package jsonapi
 
 import (
 	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rulecontext"
 	"proto.zip/studio/validate/pkg/rules"
 )
 
@@ -56,3 +61,111 @@ func (MemberNameRule) Replaces(r rules.Rule[string]) bool { return false }
 func (MemberNameRule) String() string { return "MemberNameRule" }
 
 var _ rules.Rule[string] = MemberNameRule{}
+
+// TypeNameRule validates resource type names in resource objects, resource identifiers, and included resources.
+// It defaults to MemberNameRule, which rejects empty names and reserved characters. Replace it during program
+// initialization to constrain the format further, e.g. with NewTypeNameRule.
+var TypeNameRule rules.Rule[string] = MemberNameRule{}
+
+// NewTypeNameRule returns a rule that requires a valid member name that also matches pattern.
+func NewTypeNameRule(pattern *regexp.Regexp) rules.Rule[string] {
+	return typeNamePatternRule{pattern: pattern}
+}
+
+// typeNamePatternRule is the rule returned by NewTypeNameRule.
+type typeNamePatternRule struct {
+	pattern *regexp.Regexp
+}
+
+// Evaluate implements rules.Rule[string].
+func (r typeNamePatternRule) Evaluate(ctx context.Context, value string) errors.ValidationError {
+	if err := (MemberNameRule{}).Evaluate(ctx, value); err != nil {
+		return err
+	}
+	if !r.pattern.MatchString(value) {
+		return errors.Errorf(errors.CodePattern, ctx, "invalid type", "type %q must match %s", value, r.pattern)
+	}
+	return nil
+}
+
+// Replaces implements rules.Rule[string].
+func (typeNamePatternRule) Replaces(r rules.Rule[string]) bool { return false }
+
+// String implements rules.Rule[string].
+func (r typeNamePatternRule) String() string { return "TypeNameRule(" + r.pattern.String() + ")" }
+
+// typeNameRule defers to TypeNameRule when evaluated, so package-level rule sets pick up a replacement.
+type typeNameRule struct{}
+
+// Evaluate implements rules.Rule[string].
+func (typeNameRule) Evaluate(ctx context.Context, value string) errors.ValidationError {
+	return TypeNameRule.Evaluate(ctx, value)
+}
+
+// Replaces implements rules.Rule[string].
+func (typeNameRule) Replaces(r rules.Rule[string]) bool { return false }
+
+// String implements rules.Rule[string].
+func (typeNameRule) String() string { return "TypeNameRule" }
+
+// uuidPattern matches a UUID in its canonical 8-4-4-4-12 hexadecimal form, in either case.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ulidPattern matches a ULID: 26 Crockford base32 characters, in either case, whose first character
+// keeps the timestamp within 48 bits.
+var ulidPattern = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+
+// UUIDRule validates that a string is a UUID in canonical form, e.g. for resource ids:
+//
+//	ruleSet.WithIDRule(rules.String().WithRule(jsonapi.UUIDRule))
+var UUIDRule rules.Rule[string] = rules.RuleFunc[string](func(ctx context.Context, value string) errors.ValidationError {
+	if !uuidPattern.MatchString(value) {
+		return errors.Errorf(errors.CodePattern, ctx, "invalid UUID", "%q is not a valid UUID", value)
+	}
+	return nil
+})
+
+// ULIDRule validates that a string is a ULID, e.g. for resource ids:
+//
+//	ruleSet.WithIDRule(rules.String().WithRule(jsonapi.ULIDRule))
+var ULIDRule rules.Rule[string] = rules.RuleFunc[string](func(ctx context.Context, value string) errors.ValidationError {
+	if !ulidPattern.MatchString(value) {
+		return errors.Errorf(errors.CodePattern, ctx, "invalid ULID", "%q is not a valid ULID", value)
+	}
+	return nil
+})
+
+// MetaMemberNamesRule validates that every key in a meta object, including keys of nested objects
+// and of objects inside arrays, is a valid JSON:API member name. Errors are reported at the offending key.
+var MetaMemberNamesRule rules.Rule[map[string]any] = rules.RuleFunc[map[string]any](func(ctx context.Context, meta map[string]any) errors.ValidationError {
+	return errors.Join(evaluateMemberNames(ctx, meta)...)
+})
+
+// evaluateMemberNames recursively checks object keys in value with MemberNameRule.
+func evaluateMemberNames(ctx context.Context, value any) []error {
+	var allErrors []error
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyCtx := rulecontext.WithPathString(ctx, key)
+			if err := (MemberNameRule{}).Evaluate(keyCtx, key); err != nil {
+				allErrors = append(allErrors, errors.Unwrap(err)...)
+			}
+			if strings.HasPrefix(key, "@") {
+				// The value of an @-member is not subject to member name rules.
+				continue
+			}
+			allErrors = append(allErrors, evaluateMemberNames(keyCtx, v[key])...)
+		}
+	case []any:
+		for i, item := range v {
+			allErrors = append(allErrors, evaluateMemberNames(rulecontext.WithPathString(ctx, strconv.Itoa(i)), item)...)
+		}
+	}
+	return allErrors
+}
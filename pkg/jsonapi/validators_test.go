@@ -1,10 +1,13 @@
 package jsonapi_test
 
 import (
+	"context"
+	"regexp"
 	"testing"
 
 	"proto.zip/studio/jsonapi/pkg/jsonapi"
 	"proto.zip/studio/validate/pkg/errors"
+	"proto.zip/studio/validate/pkg/rules"
 	"proto.zip/studio/validate/pkg/testhelpers"
 )
 
@@ -39,3 +42,94 @@ func TestMemberNameRule_ReplacesAndString(t *testing.T) {
 		t.Errorf("String(): got %q", s)
 	}
 }
+
+// Requirements:
+// - NewTypeNameRule rejects reserved characters like MemberNameRule and enforces its pattern.
+// - TypeNameRule rejects an invalid type identically in resource objects, resource identifiers, and included resources.
+func TestTypeNameRule(t *testing.T) {
+	rule := jsonapi.NewTypeNameRule(regexp.MustCompile(`^[a-z]+$`))
+	testhelpers.MustEvaluate(t, rule, "articles")
+	testhelpers.MustNotEvaluate(t, rule, "Articles", errors.CodePattern)
+	testhelpers.MustNotEvaluate(t, rule, "art/icles", errors.CodeUnexpected)
+	testhelpers.MustNotEvaluate(t, rule, "", errors.CodeRequired)
+
+	datumRuleSet := jsonapi.NewDatumRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+		WithTypeResolver(func(ctx context.Context, typeName string) bool { return true })
+	paths := map[string]func(map[string]any) errors.ValidationError{
+		"datum": func(input map[string]any) errors.ValidationError {
+			_, errs := datumRuleSet.Apply(context.Background(), input)
+			return errs
+		},
+		"linkage": func(input map[string]any) errors.ValidationError {
+			_, errs := jsonapi.ResourceIdentifierLinkageRuleSet.Apply(context.Background(), input)
+			return errs
+		},
+		"included": func(input map[string]any) errors.ValidationError {
+			_, errs := jsonapi.IncludedResourceRuleSet.Apply(context.Background(), input)
+			return errs
+		},
+	}
+	for name, apply := range paths {
+		t.Run(name, func(t *testing.T) {
+			if errs := apply(map[string]any{"type": "articles", "id": "1"}); errs != nil {
+				t.Errorf("Expected errors to be nil, got: %s", errs)
+			}
+			errs := apply(map[string]any{"type": "art/icles", "id": "1"})
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			unwrapped := errors.Unwrap(errs)
+			if len(unwrapped) != 1 {
+				t.Fatalf("Expected 1 error, got: %d", len(unwrapped))
+			}
+			ve := unwrapped[0].(errors.ValidationError)
+			if ve.Code() != errors.CodeUnexpected {
+				t.Errorf("Expected code %s, got: %s", errors.CodeUnexpected, ve.Code())
+			}
+			if ve.Path() != "/type" {
+				t.Errorf(`Expected path to be "/type", got: "%s"`, ve.Path())
+			}
+		})
+	}
+}
+
+// Requirements:
+// - UUIDRule accepts canonical UUIDs in either case and rejects other strings with CodePattern.
+// - ULIDRule accepts ULIDs in either case and rejects other strings with CodePattern.
+// - Used as an id rule, errors point at /data/id.
+func TestUUIDAndULIDRules(t *testing.T) {
+	testhelpers.MustEvaluate(t, jsonapi.UUIDRule, "123e4567-e89b-12d3-a456-426614174000")
+	testhelpers.MustEvaluate(t, jsonapi.UUIDRule, "123E4567-E89B-12D3-A456-426614174000")
+	testhelpers.MustNotEvaluate(t, jsonapi.UUIDRule, "123e4567e89b12d3a456426614174000", errors.CodePattern)
+	testhelpers.MustNotEvaluate(t, jsonapi.UUIDRule, "123e4567-e89b-12d3-a456-42661417400g", errors.CodePattern)
+	testhelpers.MustNotEvaluate(t, jsonapi.UUIDRule, "1", errors.CodePattern)
+
+	testhelpers.MustEvaluate(t, jsonapi.ULIDRule, "01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	testhelpers.MustEvaluate(t, jsonapi.ULIDRule, "01arz3ndektsv4rrffq69g5fav")
+	testhelpers.MustNotEvaluate(t, jsonapi.ULIDRule, "81ARZ3NDEKTSV4RRFFQ69G5FAV", errors.CodePattern)
+	testhelpers.MustNotEvaluate(t, jsonapi.ULIDRule, "01ARZ3NDEKTSV4RRFFQ69G5FAU", errors.CodePattern)
+	testhelpers.MustNotEvaluate(t, jsonapi.ULIDRule, "01ARZ3NDEKTSV4RRFFQ69G5FA", errors.CodePattern)
+
+	for name, rule := range map[string]rules.Rule[string]{"uuid": jsonapi.UUIDRule, "ulid": jsonapi.ULIDRule} {
+		t.Run(name, func(t *testing.T) {
+			ruleSet := jsonapi.NewSingleRuleSet[map[string]any]("articles", rules.StringMap[any]().WithUnknown()).
+				WithIDRule(rules.String().WithRule(rule))
+			_, errs := ruleSet.Apply(context.Background(), `{"data": {"type": "articles", "id": "1", "attributes": {}}}`)
+			if errs == nil {
+				t.Fatal("Expected errors to not be nil")
+			}
+			list := jsonapi.ErrorsFromValidationError(errs, jsonapi.SourcePointer)
+			if len(list) != 1 || list[0].Source == nil || list[0].Source.Pointer != "/data/id" {
+				t.Errorf("Expected a single error at /data/id, got: %+v", list)
+			}
+		})
+	}
+}
+
+func TestMetaMemberNamesRule(t *testing.T) {
+	rule := jsonapi.MetaMemberNamesRule
+
+	testhelpers.MustEvaluate(t, rule, map[string]any{"a": map[string]any{"b": []any{map[string]any{"c": 1}}}})
+	testhelpers.MustNotEvaluate(t, rule, map[string]any{"a": map[string]any{"b.c": 1}}, errors.CodeUnexpected)
+	testhelpers.MustNotEvaluate(t, rule, map[string]any{"a": []any{map[string]any{"b c": 1}}}, errors.CodeUnexpected)
+}